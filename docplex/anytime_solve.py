@@ -0,0 +1,87 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to wrap a time limited solve so that the caller always
+# gets the best solution found together with a machine readable statement
+# about its quality, instead of having to interpret CPLEX status codes.
+#
+# The quality is one of
+# - 'optimal': the solution is proven optimal (within the gap tolerances),
+# - 'within_gap': the solution is proven to be within the reported relative
+#   gap of the optimum,
+# - 'feasible': a solution was found but no useful bound is available,
+# - 'infeasible': the model is proven infeasible,
+# - 'unbounded': the model is proven unbounded,
+# - 'infeasible_or_unbounded': the model is infeasible or unbounded, but
+#   CPLEX did not determine which (see inf_or_unbd.py to find out),
+# - 'nothing': no solution was found within the budget.
+#
+# Usage: python anytime_solve.py <model file> <seconds>
+import json
+import sys
+
+from docplex.mp.model_reader import ModelReader
+
+
+def solve_anytime(mdl, budget, gap_threshold=1e-2, **kwargs):
+    """Solve MDL with a time limit of BUDGET seconds.
+
+    Returns a tuple (solution, quality) where solution may be None and
+    quality is a dictionary with keys 'quality', 'objective', 'bound',
+    'gap', 'status' and 'time'. A solution is only qualified as
+    'within_gap' if its relative gap is below GAP_THRESHOLD (default 1%).
+    """
+    mdl.parameters.timelimit = budget
+    sol = mdl.solve(**kwargs)
+    details = mdl.solve_details
+    status = details.status
+    quality = {'status': status, 'time': details.time,
+               'objective': None, 'bound': None, 'gap': None}
+    if sol is None:
+        if details.status_code in (3, 103):
+            # CPX_STAT_INFEASIBLE, CPXMIP_INFEASIBLE
+            quality['quality'] = 'infeasible'
+        elif details.status_code in (2, 118):
+            # CPX_STAT_UNBOUNDED, CPXMIP_UNBOUNDED
+            quality['quality'] = 'unbounded'
+        elif details.status_code in (4, 119):
+            # CPX_STAT_INForUNBD, CPXMIP_INForUNBD
+            quality['quality'] = 'infeasible_or_unbounded'
+        else:
+            quality['quality'] = 'nothing'
+        return None, quality
+
+    quality['objective'] = sol.get_objective_value()
+    if not any(v.is_discrete() for v in mdl.iter_variables()):
+        # For continuous models a solution is only returned if optimal or
+        # if a limit was hit in which case there is no bound.
+        quality['quality'] = 'feasible' if details.has_hit_limit() else 'optimal'
+        if quality['quality'] == 'optimal':
+            quality['bound'] = quality['objective']
+            quality['gap'] = 0.0
+        return sol, quality
+
+    quality['bound'] = details.best_bound
+    quality['gap'] = details.mip_relative_gap
+    if not details.has_hit_limit():
+        quality['quality'] = 'optimal'
+    elif quality['gap'] is not None and quality['gap'] < gap_threshold:
+        quality['quality'] = 'within_gap'
+    else:
+        quality['quality'] = 'feasible'
+    return sol, quality
+
+
+if __name__ == "__main__":
+    m = ModelReader.read(sys.argv[1])
+    sol, quality = solve_anytime(m, float(sys.argv[2]))
+    json.dump(quality, sys.stdout, indent=2, sort_keys=True)
+    print()
+    if quality['quality'] in ('optimal', 'within_gap'):
+        print('Solution can be used: objective %f' % quality['objective'])
+    elif quality['quality'] == 'feasible':
+        print('Solution is feasible but its quality is unknown')
+    m.end()
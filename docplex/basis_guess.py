@@ -0,0 +1,113 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to start the dual simplex from a basis that was not
+# produced by CPLEX for the model at hand, for example a basis that was
+# optimal for yesterday's version of a model.
+#
+# The basis guess is given as statuses by variable and constraint name. It is
+# mapped to the new model and then repaired so that it is a valid starting
+# basis:
+# - variables/constraints without a guess are nonbasic at a bound (slacks
+#   are basic),
+# - a variable cannot be nonbasic at an infinite bound,
+# - the number of basic variables must equal the number of rows; if there
+#   are too many, basic structurals with the smallest index are made
+#   nonbasic, if there are too few, slacks are made basic.
+# If the resulting basis is singular, CPLEX repairs it by replacing columns
+# with slacks.
+#
+# Usage: python basis_guess.py <yesterday model file> <today model file>
+import sys
+
+import cplex
+
+BASIC = 1
+AT_LOWER = 0
+AT_UPPER = 2
+FREE = 3
+
+
+def basis_by_name(cpx):
+    """Get the optimal basis of CPX as two dictionaries keyed by names."""
+    cstat, rstat = cpx.solution.basis.get_basis()
+    return (dict(zip(cpx.variables.get_names(), cstat)),
+            dict(zip(cpx.linear_constraints.get_names(), rstat)))
+
+
+def repair(cpx, cstat, rstat):
+    """Repair the basis given by CSTAT and RSTAT for CPX in place."""
+    lb = cpx.variables.get_lower_bounds()
+    ub = cpx.variables.get_upper_bounds()
+    repaired = 0
+    for j in range(len(cstat)):
+        s = cstat[j]
+        if s == AT_LOWER and lb[j] <= -cplex.infinity:
+            s = AT_UPPER if ub[j] < cplex.infinity else FREE
+        if s == AT_UPPER and ub[j] >= cplex.infinity:
+            s = AT_LOWER if lb[j] > -cplex.infinity else FREE
+        if s == FREE and (lb[j] > -cplex.infinity or ub[j] < cplex.infinity):
+            s = AT_LOWER if lb[j] > -cplex.infinity else AT_UPPER
+        if s != cstat[j]:
+            cstat[j] = s
+            repaired += 1
+    nbasic = cstat.count(BASIC) + rstat.count(BASIC)
+    m = len(rstat)
+    j = 0
+    while nbasic > m and j < len(cstat):
+        if cstat[j] == BASIC:
+            cstat[j] = AT_LOWER if lb[j] > -cplex.infinity else \
+                       (AT_UPPER if ub[j] < cplex.infinity else FREE)
+            nbasic -= 1
+            repaired += 1
+        j += 1
+    i = 0
+    while nbasic < m and i < m:
+        if rstat[i] != BASIC:
+            rstat[i] = BASIC
+            nbasic += 1
+            repaired += 1
+        i += 1
+    return repaired
+
+
+def set_basis_guess(cpx, colguess, rowguess):
+    """Install the basis guess COLGUESS/ROWGUESS (dictionaries keyed by
+    names) as starting basis for CPX."""
+    cstat = [colguess.get(name, AT_LOWER) for name in cpx.variables.get_names()]
+    rstat = [rowguess.get(name, BASIC)
+             for name in cpx.linear_constraints.get_names()]
+    repaired = repair(cpx, cstat, rstat)
+    print('Basis guess: %d column and %d row statuses mapped, %d repairs' %
+          (sum(1 for n in cpx.variables.get_names() if n in colguess),
+           sum(1 for n in cpx.linear_constraints.get_names() if n in rowguess),
+           repaired))
+    cpx.start.set_start(cstat, rstat, [], [], [], [])
+
+
+def solve(filename, guess=None):
+    cpx = cplex.Cplex(filename)
+    cpx.set_results_stream(None)
+    cpx.parameters.lpmethod.set(cpx.parameters.lpmethod.values.dual)
+    # Make sure the start is used.
+    cpx.parameters.advance.set(1)
+    if guess is not None:
+        set_basis_guess(cpx, guess[0], guess[1])
+    cpx.solve()
+    status = '%s: %s' % (filename, cpx.solution.get_status_string())
+    if cpx.solution.is_primal_feasible():
+        status += ', objective %f' % cpx.solution.get_objective_value()
+    print('%s, %d iterations' %
+          (status, cpx.solution.progress.get_num_iterations()))
+    return cpx
+
+
+if __name__ == "__main__":
+    yesterday = solve(sys.argv[1])
+    guess = basis_by_name(yesterday)
+    yesterday.end()
+    solve(sys.argv[2]).end()          # Cold start for comparison
+    solve(sys.argv[2], guess).end()   # Warm start from basis guess
@@ -0,0 +1,324 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to implement a Benders decomposition with DOcplex,
+# including some of the acceleration techniques that make Benders work in
+# practice.
+#
+# The problem is the uncapacitated facility location problem: open
+# facilities y[i] (binary) and serve each customer j from open facilities.
+# The master problem holds the y variables and one variable theta[j] per
+# customer that estimates the cost of serving j. The subproblem for
+# customer j is the dual of the assignment LP for fixed y:
+#   max v - sum_i y[i] w[i]   s.t.  v - w[i] <= c[i][j],  w >= 0
+# Its optimal solution gives the optimality cut
+#   theta[j] >= v - sum_i w[i] y[i].
+#
+# The following accelerations can be selected with options:
+# - 'trust_region': a local branching constraint limits the number of y
+#   that may change with respect to the best solution found so far. Once
+#   the method converges with the trust region it is removed so that the
+#   result is globally optimal.
+# - 'in_out': cuts are separated at a point between an interior "core"
+#   point and the master solution, which avoids the zig-zagging of cuts
+#   generated at extreme points. If that point yields no violated cut, the
+#   master solution itself is used.
+# - 'magnanti_wong': among all optimal subproblem solutions choose the one
+#   that gives the strongest cut at the core point (Pareto-optimal cuts).
+# - 'cut_pool': cuts are managed by the CutPool of cut_loop.py, which drops
+#   duplicate cuts, purges cuts that have not been binding for a while and
+#   adds them back when the master solution violates them again.
+#
+# The subproblems are independent, so they can be solved concurrently by a
+# pool of worker threads. Each subproblem has its own model and thus its own
+# CPLEX environment. Results are collected and cuts are added in customer
+# order, no matter in which order the subproblems finish, so that runs are
+# reproducible.
+#
+# Instead of solving the master to optimality in each iteration, Benders can
+# also be implemented in a single search tree: benders_single_tree() solves
+# the master once and generates cuts from a lazy constraint callback each
+# time CPLEX finds a candidate incumbent. The subproblems for a candidate are
+# solved concurrently by the worker pool, but the callback waits for all of
+# them before it returns: the tree search of the calling CPLEX thread does not
+# continue while the cuts are computed. Cuts are not added asynchronously.
+# Since CPLEX may invoke the callback from several threads at the same time,
+# each callback thread uses its own copies of the subproblems. The cut pool is
+# not available there: lazy constraints added from a callback are managed by
+# CPLEX and cannot be purged by the user.
+#
+# Usage: python benders.py [trust_region] [in_out] [magnanti_wong] [cut_pool]
+#                           [workers=N]
+#        python benders.py single_tree [magnanti_wong] [workers=N]
+import random
+import sys
+import threading
+from concurrent.futures import ThreadPoolExecutor
+
+from cplex.callbacks import LazyConstraintCallback
+
+from docplex.mp.callbacks.cb_mixin import *
+from docplex.mp.model import Model
+
+from cut_loop import CutPool
+
+
+class Subproblem(object):
+    """Dual of the assignment LP of one customer."""
+
+    def __init__(self, cost):
+        self.mdl = Model(name='sub')
+        # Parallelism comes from solving several subproblems at once.
+        self.mdl.parameters.threads = 1
+        self.Facilities = range(len(cost))
+        self.v = self.mdl.continuous_var(lb=-self.mdl.infinity, name='v')
+        self.w = self.mdl.continuous_var_list(self.Facilities, name='w')
+        for i in self.Facilities:
+            self.mdl.add_constraint(self.v - self.w[i] <= cost[i])
+
+    def value(self, y):
+        return self.v - self.mdl.sum(y[i] * self.w[i] for i in self.Facilities)
+
+    def solve(self, y, core=None, tol=1e-6):
+        """Solve the subproblem for the facilities Y (a list of values).
+        If CORE is given, return the Pareto-optimal cut for this core point.
+        Returns (z, v, w): the optimal value and the cut coefficients."""
+        self.mdl.maximize(self.value(y))
+        sol = self.mdl.solve()
+        assert sol is not None
+        z = sol.get_objective_value()
+        if core is not None:
+            # Magnanti-Wong: among all optimal solutions pick the one that
+            # maximizes the cut at the core point.
+            ct = self.mdl.add_constraint(self.value(y) >= z - tol)
+            self.mdl.maximize(self.value(core))
+            sol = self.mdl.solve()
+            self.mdl.remove_constraint(ct)
+            assert sol is not None
+        return z, sol.get_value(self.v), [sol.get_value(wi) for wi in self.w]
+
+    def end(self):
+        self.mdl.end()
+
+
+def solve_all(executor, subs, y, core=None):
+    """Solve all subproblems SUBS for Y. Results are returned in the order
+    of SUBS, independent of the order in which the solves finish."""
+    if executor is None:
+        return [s.solve(y, core) for s in subs]
+    return list(executor.map(lambda s: s.solve(y, core), subs))
+
+
+def build_master(fixed, nbCustomers):
+    Facilities = range(len(fixed))
+    Customers = range(nbCustomers)
+    master = Model(name='master')
+    y = master.binary_var_list(Facilities, name='y')
+    theta = master.continuous_var_list(Customers, name='theta')
+    master.add_constraint(master.sum(y) >= 1)
+    master.minimize(master.sum(fixed[i] * y[i] for i in Facilities) +
+                    master.sum(theta))
+    return master, y, theta
+
+
+def benders(fixed, cost, trust_region=False, in_out=False,
+            magnanti_wong=False, cut_pool=False, workers=1,
+            max_iterations=200, tol=1e-6):
+    """Solve the facility location problem with opening costs FIXED[i] and
+    serving costs COST[i][j] by Benders decomposition. WORKERS is the
+    number of subproblems that are solved concurrently."""
+    Facilities = range(len(fixed))
+    Customers = range(len(cost[0]))
+    subs = [Subproblem([cost[i][j] for i in Facilities]) for j in Customers]
+    core = [0.5] * len(fixed)
+
+    master, y, theta = build_master(fixed, len(Customers))
+    pool = CutPool(master, tol=tol) if cut_pool else None
+
+    executor = ThreadPoolExecutor(max_workers=workers) if workers > 1 else None
+    mw = core if magnanti_wong else None
+    best = None
+    best_y = None
+    region = None
+    delta = max(1, len(fixed) // 5)
+    for it in range(max_iterations):
+        sol = master.solve()
+        assert sol is not None
+        lb = sol.get_objective_value()
+        yval = [sol.get_value(yi) for yi in y]
+        tval = [sol.get_value(t) for t in theta]
+
+        if pool is not None:
+            purged = pool.update(sol)
+            readded = pool.recheck(sol)
+            if readded > 0:
+                # Cuts from the pool are violated, no need to solve the
+                # subproblems.
+                print('Iteration %d: master %f, %d cuts purged, '
+                      '%d re-added from pool' % (it, lb, purged, readded))
+                continue
+
+        # Evaluate the master solution and generate cuts.
+        ub = sum(fixed[i] * yval[i] for i in Facilities)
+        sep = yval
+        if in_out:
+            sep = [0.5 * core[i] + 0.5 * yval[i] for i in Facilities]
+        cuts = list()
+        if in_out:
+            ub += sum(z for z, v, w in solve_all(executor, subs, yval))
+            results = solve_all(executor, subs, sep, mw)
+        else:
+            results = solve_all(executor, subs, yval, mw)
+            ub += sum(z for z, v, w in results)
+        for j in Customers:
+            z, v, w = results[j]
+            # Add the cut if it is violated by the master solution.
+            if v - sum(w[i] * yval[i] for i in Facilities) > tval[j] + tol:
+                cuts.append(theta[j] >= v - master.sum(w[i] * y[i]
+                                                       for i in Facilities))
+        if in_out and len(cuts) == 0:
+            # No violated cut at the separation point, use the master
+            # solution itself.
+            results = solve_all(executor, subs, yval, mw)
+            for j in Customers:
+                z, v, w = results[j]
+                if z > tval[j] + tol:
+                    cuts.append(theta[j] >= v - master.sum(w[i] * y[i]
+                                                           for i in Facilities))
+        if in_out:
+            # Move the core point towards the separation point.
+            core = sep
+            mw = core if magnanti_wong else None
+
+        if best is None or ub < best - tol:
+            best = ub
+            best_y = yval
+            if trust_region:
+                # Recenter the trust region around the new incumbent.
+                if region is not None:
+                    master.remove_constraint(region)
+                region = master.add_constraint(
+                    master.sum(1 - y[i] if best_y[i] > 0.5 else y[i]
+                               for i in Facilities) <= delta)
+
+        # Note that the master objective is only a lower bound if there is
+        # no trust region.
+        print('Iteration %d: master %f, upper bound %f, %d cuts%s' %
+              (it, lb, best, len(cuts),
+               ', trust region' if region is not None else ''))
+        if len(cuts) == 0:
+            if region is not None:
+                # Converged within the trust region. Remove it to make sure
+                # the result is globally optimal.
+                master.remove_constraint(region)
+                region = None
+                trust_region = False
+                continue
+            break
+        if pool is not None:
+            pool.add(cuts)
+        else:
+            master.add_constraints(cuts)
+
+    if executor is not None:
+        executor.shutdown()
+    for s in subs:
+        s.end()
+    master.end()
+    return best, best_y
+
+
+class BendersCallback(ConstraintCallbackMixin, LazyConstraintCallback):
+    """Lazy constraint callback that separates Benders cuts at candidate
+    incumbents."""
+
+    def __init__(self, env):
+        LazyConstraintCallback.__init__(self, env)
+        ConstraintCallbackMixin.__init__(self)
+        self.local = threading.local()
+
+    def subproblems(self):
+        """Get the subproblems owned by the calling thread."""
+        if not hasattr(self.local, 'subs'):
+            self.local.subs = [Subproblem([self.cost[i][j]
+                                           for i in range(len(self.y))])
+                               for j in range(len(self.theta))]
+            # Remember the copies so that they can be ended at the end.
+            with self.lock:
+                self.allsubs.extend(self.local.subs)
+        return self.local.subs
+
+    def __call__(self):
+        # Building solutions and expressions touches the master model, which
+        # is shared by all callback threads.
+        with self.lock:
+            sol = self.make_solution_from_vars(self.y + self.theta)
+        yval = [sol.get_value(yi) for yi in self.y]
+        # Blocks until all subproblems of this candidate are solved.
+        results = solve_all(self.executor, self.subproblems(), yval, self.core)
+        with self.lock:
+            cuts = list()
+            for j, (z, v, w) in enumerate(results):
+                cuts.append(self.theta[j] >= v - self.mdl.sum(w[i] * self.y[i]
+                                                              for i in range(len(yval))))
+            unsats = self.get_cpx_unsatisfied_cts(cuts, sol, tolerance=1e-6)
+            self.ncuts += len(unsats)
+        for ct, cpx_lhs, sense, cpx_rhs in unsats:
+            self.add(cpx_lhs, sense, cpx_rhs)
+
+
+def benders_single_tree(fixed, cost, magnanti_wong=False, workers=1):
+    """Solve the facility location problem by Benders decomposition with
+    cuts generated in a lazy constraint callback."""
+    master, y, theta = build_master(fixed, len(cost[0]))
+    executor = ThreadPoolExecutor(max_workers=workers) if workers > 1 else None
+    cb = master.register_callback(BendersCallback)
+    cb.mdl = master
+    cb.y = y
+    cb.theta = theta
+    cb.cost = cost
+    cb.core = [0.5] * len(fixed) if magnanti_wong else None
+    cb.executor = executor
+    cb.lock = threading.Lock()
+    cb.allsubs = list()
+    cb.ncuts = 0
+    sol = master.solve(log_output=True)
+    assert sol is not None
+    print('%d cuts added' % cb.ncuts)
+    obj = sol.get_objective_value()
+    yval = [sol.get_value(yi) for yi in y]
+    if executor is not None:
+        executor.shutdown()
+    for s in cb.allsubs:
+        s.end()
+    master.end()
+    return obj, yval
+
+
+if __name__ == "__main__":
+    rnd = random.Random(42)
+    nbFacilities = 20
+    nbCustomers = 50
+    fixed = [rnd.randint(100, 200) for i in range(nbFacilities)]
+    cost = [[rnd.randint(5, 50) for j in range(nbCustomers)]
+            for i in range(nbFacilities)]
+    workers = 1
+    for arg in sys.argv[1:]:
+        if arg.startswith('workers='):
+            workers = int(arg[8:])
+    if 'single_tree' in sys.argv:
+        obj, y = benders_single_tree(fixed, cost,
+                                     magnanti_wong='magnanti_wong' in sys.argv,
+                                     workers=workers)
+    else:
+        obj, y = benders(fixed, cost,
+                         trust_region='trust_region' in sys.argv,
+                         in_out='in_out' in sys.argv,
+                         magnanti_wong='magnanti_wong' in sys.argv,
+                         cut_pool='cut_pool' in sys.argv,
+                         workers=workers)
+    print('Optimal cost %f, open facilities: %s' %
+          (obj, [i for i, yi in enumerate(y) if yi > 0.5]))
@@ -0,0 +1,98 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to audit the variable bounds of a DOcplex model for
+# mistakes with infinite and default bounds.
+#
+# CPLEX treats every bound with an absolute value of at least 1e20 as
+# infinite. INF and NEG_INF are these values; use them (or
+# Model.infinity) to state infinite bounds explicitly. Common mistakes are:
+# - 'huge': a finite bound that is meant to be infinite, such as 1e15 or
+#   9.9e19. Values below 1e20 are kept as finite bounds and can cause
+#   numerical trouble,
+# - 'not_free': a continuous variable created with the default lower bound
+#   0 although it was meant to be free. Variables are declared free by a
+#   name prefix (for example 'balance' or 'delta'),
+# - 'binding_default': a variable with default bounds that sits at its
+#   lower bound 0 in an LP solution while the objective would improve if it
+#   could become negative (the reduced cost shows that the bound is
+#   binding). This is only a hint: these are exactly the variables for which
+#   the default bound matters, so check that it was intended.
+# With list_default=True, audit() also lists all variables with the
+# default bounds [0, +inf).
+#
+# The audit works on the model alone, binding_defaults() needs an LP
+# solution of the model.
+from collections import namedtuple
+
+from docplex.mp.model import Model
+
+INF = 1e20
+NEG_INF = -1e20
+
+Finding = namedtuple('Finding', ['variable', 'kind', 'message'])
+
+
+def audit(mdl, huge=1e10, free_prefixes=(), list_default=False):
+    """Return a list of Findings for the variable bounds of MDL. Finite
+    bounds with absolute value at least HUGE are reported as 'huge',
+    continuous variables whose name starts with one of FREE_PREFIXES and
+    whose lower bound is 0 as 'not_free'."""
+    findings = list()
+    for v in mdl.iter_variables():
+        for side, b in (('lower', v.lb), ('upper', v.ub)):
+            if huge <= abs(b) < INF:
+                inf = 'NEG_INF' if b < 0 else 'INF'
+                findings.append(Finding(
+                    v, 'huge', '%s bound %g is finite; use %s if it is meant '
+                    'to be infinite' % (side, b, inf)))
+        if not v.is_continuous():
+            continue
+        name = v.name or ''
+        if v.lb == 0 and any(name.startswith(p) for p in free_prefixes):
+            findings.append(Finding(v, 'not_free', 'lower bound is 0 but the '
+                                    'variable is declared free'))
+        elif list_default and v.lb == 0 and v.ub >= INF:
+            findings.append(Finding(v, 'default', 'default bounds [0, +inf)'))
+    return findings
+
+
+def binding_defaults(mdl, tol=1e-9):
+    """Return Findings for the continuous variables of the solved LP MDL
+    with default bounds that are at their lower bound 0 while their reduced
+    cost shows that the objective would improve if they could become
+    negative."""
+    findings = list()
+    sign = 1 if mdl.objective_sense.is_minimize() else -1
+    variables = [v for v in mdl.iter_continuous_vars()
+                 if v.lb == 0 and v.ub >= INF and abs(v.solution_value) <= tol]
+    for v, rc in zip(variables, mdl.reduced_costs(variables)):
+        if sign * rc > tol:
+            findings.append(Finding(v, 'binding_default',
+                                    'at lower bound 0 with reduced cost %g' %
+                                    rc))
+    return findings
+
+
+if __name__ == "__main__":
+    with Model(name='cash_flow') as m:
+        periods = range(4)
+        income = [50, -20, -40, 30]
+        # The cash balance may become negative (an overdraft), but the
+        # variables were created with the default lower bound.
+        balance = m.continuous_var_list(periods, name='balance')
+        loan = m.continuous_var_list(periods, ub=1e15, name='loan')
+        for t in periods:
+            prev = balance[t - 1] if t > 0 else 0
+            m.add_constraint(balance[t] == prev + income[t] + loan[t],
+                             'flow_%d' % t)
+        m.minimize(m.sum(0.05 * loan[t] for t in periods) +
+                   m.sum(0.1 * balance[t] for t in periods))
+        for f in audit(m, free_prefixes=['balance'], list_default=True):
+            print('%-10s %-16s %s' % (f.variable.name, f.kind, f.message))
+        if m.solve() is not None:
+            for f in binding_defaults(m):
+                print('%-10s %-16s %s' % (f.variable.name, f.kind, f.message))
@@ -0,0 +1,140 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to propagate variable bounds through the linear
+# constraints of a DOcplex model before solving it.
+#
+# For a constraint sum_j a[j] x[j] <= b, the minimal activity of all terms
+# but x[k] gives an upper bound (a[k] > 0) or lower bound (a[k] < 0) for
+# x[k]. Repeating this for all constraints until no bound changes any more
+# gives tightened bounds, which are rounded for integer variables. A row
+# whose minimal activity already exceeds its right-hand side can never be
+# satisfied; such rows are reported as infeasible. Doing this while the
+# model is being built points directly at the data or code that produced
+# the offending row, which is much easier than interpreting an infeasible
+# solve later.
+#
+# No solver is needed, so this also works without a CPLEX runtime.
+import math
+
+from docplex.mp.model import Model
+
+
+def extract_rows(mdl):
+    """Get the linear constraints of MDL as (name, terms, sense, rhs) where
+    terms is a list of (variable, coefficient) pairs and sense is 'LE',
+    'GE' or 'EQ'. Range constraints yield two rows."""
+    rows = list()
+    for ct in mdl.iter_linear_constraints():
+        if hasattr(ct, 'get_left_expr'):
+            expr = ct.get_left_expr() - ct.get_right_expr()
+            terms = [(v, c) for v, c in expr.iter_terms() if c != 0]
+            rows.append((ct.name, terms, ct.sense.name, -expr.get_constant()))
+        else:
+            terms = [(v, c) for v, c in ct.expr.iter_terms() if c != 0]
+            k = ct.expr.get_constant()
+            rows.append((ct.name, terms, 'GE', ct.lb - k))
+            rows.append((ct.name, terms, 'LE', ct.ub - k))
+    return rows
+
+
+def propagate_rows(rows, bounds, is_integer, max_passes=20, tol=1e-9):
+    """Propagate BOUNDS (a dictionary mapping variables to [lb, ub]) through
+    ROWS. IS_INTEGER is a function telling whether a variable is integral.
+    BOUNDS is updated in place. Returns the list of names of rows that are
+    infeasible."""
+    inf = float('inf')
+    infeasible = list()
+    for p in range(max_passes):
+        changed = False
+        for name, terms, sense, rhs in rows:
+            # Handle >= rows and both sides of == rows as <= rows.
+            senses = {'LE': [1], 'GE': [-1], 'EQ': [1, -1]}[sense]
+            for sign in senses:
+                coefs = [(v, sign * c) for v, c in terms]
+                b = sign * rhs
+                minact = 0.0
+                ninf = 0
+                for v, c in coefs:
+                    lb, ub = bounds[v]
+                    m = c * lb if c > 0 else c * ub
+                    if m == -inf:
+                        ninf += 1
+                    else:
+                        minact += m
+                if ninf == 0 and minact > b + tol * max(1.0, abs(b)):
+                    if name not in infeasible:
+                        infeasible.append(name)
+                    continue
+                for v, c in coefs:
+                    lb, ub = bounds[v]
+                    m = c * lb if c > 0 else c * ub
+                    if m == -inf:
+                        if ninf > 1:
+                            continue
+                        rest = minact
+                    elif ninf > 0:
+                        continue
+                    else:
+                        rest = minact - m
+                    # c * v <= b - rest
+                    limit = (b - rest) / c
+                    if c > 0:
+                        if is_integer(v):
+                            limit = math.floor(limit + tol)
+                        if limit < ub - tol * max(1.0, abs(limit)):
+                            bounds[v][1] = limit
+                            changed = True
+                    else:
+                        if is_integer(v):
+                            limit = math.ceil(limit - tol)
+                        if limit > lb + tol * max(1.0, abs(limit)):
+                            bounds[v][0] = limit
+                            changed = True
+        if not changed:
+            break
+    for v, (lb, ub) in bounds.items():
+        if lb > ub + tol * max(1.0, abs(ub)):
+            infeasible.append('bounds of %s' % v)
+    return infeasible
+
+
+def propagate(mdl, apply=True, **kwargs):
+    """Propagate bounds for MDL. If APPLY is true, the tightened bounds are
+    written back to the model. Returns the tightened bounds by variable and
+    the list of infeasible rows."""
+    inf = float('inf')
+    bounds = dict()
+    for v in mdl.iter_variables():
+        lb = -inf if v.lb <= -mdl.infinity else v.lb
+        ub = inf if v.ub >= mdl.infinity else v.ub
+        bounds[v] = [lb, ub]
+    infeasible = propagate_rows(extract_rows(mdl), bounds,
+                                lambda v: v.is_discrete(), **kwargs)
+    if apply and len(infeasible) == 0:
+        for v, (lb, ub) in bounds.items():
+            if lb > -inf and lb > v.lb:
+                v.lb = lb
+            if ub < inf and ub < v.ub:
+                v.ub = ub
+    return bounds, infeasible
+
+
+if __name__ == "__main__":
+    with Model(name='propagation') as m:
+        x = m.integer_var(ub=100, name='x')
+        y = m.continuous_var(name='y')
+        z = m.continuous_var(lb=-m.infinity, name='z')
+        m.add_constraint(2 * x + y <= 9, 'c1')
+        m.add_constraint(y >= 3, 'c2')
+        m.add_constraint(z == x + y, 'c3')
+        bounds, infeasible = propagate(m)
+        for v in (x, y, z):
+            print('%s in [%g, %g]' % (v.name, v.lb, v.ub))
+        # Now add a row that cannot be satisfied with these bounds.
+        m.add_constraint(x + y >= 20, 'c4')
+        bounds, infeasible = propagate(m)
+        print('Infeasible rows: %s' % ', '.join(infeasible))
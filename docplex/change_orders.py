@@ -0,0 +1,107 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to turn the difference between two plans into a list
+# of change orders that can be fed to an execution system.
+#
+# It builds on solution_diff.py: the changes are grouped by variable family
+# and for each family an extractor function turns the changes into actions.
+# Three kinds of extractors are provided:
+# - switch_extractor: binary on/off decisions become start/stop actions,
+# - quantity_extractor: continuous quantities become increase/decrease
+#   actions with the delta,
+# - move_extractor: assignment variables like 'ship_<item>_<truck>' become
+#   move actions if an item leaves one truck and arrives at another.
+# Extractors for other families can be plugged in by passing a different
+# dictionary to change_orders().
+#
+# Usage: python change_orders.py <old.json> <new.json>
+import json
+import sys
+
+from solution_diff import diff, load_values
+
+
+def switch_extractor(start='start', stop='stop'):
+    def extract(changes):
+        actions = list()
+        for c in changes:
+            if c.new > 0.5 and c.old <= 0.5:
+                actions.append({'action': start, 'target': c.name})
+            elif c.new <= 0.5 and c.old > 0.5:
+                actions.append({'action': stop, 'target': c.name})
+        return actions
+    return extract
+
+
+def quantity_extractor():
+    def extract(changes):
+        return [{'action': 'increase' if c.new > c.old else 'decrease',
+                 'target': c.name, 'quantity': abs(c.new - c.old)}
+                for c in changes]
+    return extract
+
+
+def split_last(name):
+    """Split 'ship_3_truck2' into ('ship_3', 'truck2'). Names without '_'
+    have an empty location."""
+    parts = name.rsplit('_', 1)
+    return (parts[0], parts[1]) if len(parts) == 2 else (name, '')
+
+
+def move_extractor(split=split_last):
+    """Extractor for assignment variables. SPLIT maps a variable name to a
+    pair (item, location)."""
+    def extract(changes):
+        removed = dict()
+        added = dict()
+        for c in changes:
+            item, where = split(c.name)
+            if c.new > 0.5 and c.old <= 0.5:
+                added[item] = where
+            elif c.new <= 0.5 and c.old > 0.5:
+                removed[item] = where
+        actions = list()
+        for item in sorted(set(removed) | set(added)):
+            if item in removed and item in added:
+                actions.append({'action': 'move', 'target': item,
+                                'from': removed[item], 'to': added[item]})
+            elif item in added:
+                actions.append({'action': 'assign', 'target': item,
+                                'to': added[item]})
+            else:
+                actions.append({'action': 'unassign', 'target': item,
+                                'from': removed[item]})
+        return actions
+    return extract
+
+
+def change_orders(old, new, extractors, default=None, **kwargs):
+    """Compute change orders between solutions OLD and NEW.
+    EXTRACTORS maps families to extractor functions. Families without an
+    extractor are handled by DEFAULT or ignored if DEFAULT is None. Other
+    arguments are passed to diff()."""
+    orders = list()
+    for fam, changes in sorted(diff(old, new, **kwargs).items()):
+        extract = extractors.get(fam, default)
+        if extract is None:
+            continue
+        for action in extract(changes):
+            action['family'] = fam
+            orders.append(action)
+    return orders
+
+
+if __name__ == "__main__":
+    extractors = {
+        'open': switch_extractor('open', 'close'),
+        'ship': move_extractor(),
+        'flow': quantity_extractor(),
+    }
+    orders = change_orders(load_values(sys.argv[1]), load_values(sys.argv[2]),
+                           extractors)
+    json.dump(orders, sys.stdout, indent=2)
+    print()
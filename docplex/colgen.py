@@ -0,0 +1,378 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to implement column generation for the cutting stock
+# problem with the CPLEX Python API.
+#
+# The restricted master problem is maintained with row templates: each row
+# of the master is declared once together with a function that computes the
+# coefficient of the row for a column. When a new column is added, its
+# coefficients in all rows are computed by the templates, so the code that
+# generates columns does not need to know about the rows at all. Rows that
+# are declared after columns were added automatically get the coefficients
+# of the existing columns.
+#
+# All generated columns are kept in a column pool, but only the active ones
+# are part of the LP. Columns that have not been used for a number of
+# iterations and whose reduced cost is above a threshold are deactivated
+# (removed from the LP) to keep the master small. Before each call to the
+# pricing problem, the inactive columns are screened by their reduced cost,
+# which is cheap, and the ones that price out are reactivated.
+#
+# The duals of the master tend to oscillate, which makes plain column
+# generation tail off. The Stabilization class implements three configurable
+# stabilization strategies (smoothing, boxstep and piecewise penalties) and
+# the main loop prints per-iteration diagnostics: master objective,
+# Lagrangian bound, reduced cost of the priced column and mispricings.
+#
+# Columns are cutting patterns, that is dictionaries that map item indices
+# to the number of times the item is cut from a roll. The pricing problem is
+# an integer knapsack problem which is solved by dynamic programming.
+#
+# Usage: python colgen.py [none|smoothing|boxstep|piecewise]
+import sys
+
+import cplex
+
+
+class RowTemplate(object):
+    """A row of the master problem.
+    COEF is a function that maps a column to its coefficient in this row.
+    It returns 0 for columns that do not appear in the row."""
+
+    def __init__(self, name, sense, rhs, coef):
+        self.name = name
+        self.sense = sense
+        self.rhs = rhs
+        self.coef = coef
+
+
+class Master(object):
+    """Restricted master problem maintained with row templates."""
+
+    def __init__(self):
+        self.cpx = cplex.Cplex()
+        self.cpx.set_results_stream(None)
+        self.cpx.set_log_stream(None)
+        self.cpx.objective.set_sense(self.cpx.objective.sense.minimize)
+        self.templates = list()
+        # The column pool. Maps column names to (column, cost).
+        self.columns = dict()
+        # Names of the columns that are in the LP.
+        self.active = set()
+        # Number of consecutive iterations each active column was unused.
+        self.age = dict()
+
+    def add_row(self, template):
+        """Add a row to the master. Coefficients for existing columns are
+        computed from the template."""
+        ind = list()
+        val = list()
+        for name in self.active:
+            c = template.coef(self.columns[name][0])
+            if c != 0:
+                ind.append(name)
+                val.append(c)
+        self.cpx.linear_constraints.add(
+            lin_expr=[cplex.SparsePair(ind=ind, val=val)],
+            senses=[template.sense], rhs=[template.rhs],
+            names=[template.name])
+        self.templates.append(template)
+
+    def add_column(self, name, column, cost):
+        """Add a column to the pool and activate it."""
+        self.columns[name] = (column, cost)
+        self.activate(name)
+
+    def activate(self, name):
+        """Add column NAME from the pool to the LP. Its coefficients are
+        computed by the row templates."""
+        column, cost = self.columns[name]
+        ind = list()
+        val = list()
+        for t in self.templates:
+            c = t.coef(column)
+            if c != 0:
+                ind.append(t.name)
+                val.append(c)
+        self.cpx.variables.add(obj=[cost], lb=[0.0], names=[name],
+                               columns=[cplex.SparsePair(ind=ind, val=val)])
+        self.active.add(name)
+        self.age[name] = 0
+
+    def deactivate(self, name):
+        """Remove column NAME from the LP. It stays in the pool."""
+        self.cpx.variables.delete(name)
+        self.active.remove(name)
+        del self.age[name]
+
+    def reduced_cost(self, name, duals):
+        column, cost = self.columns[name]
+        return cost - sum(t.coef(column) * duals[t.name] for t in self.templates)
+
+    def manage_pool(self, values, duals, threshold=0.1, max_age=5, eps=1e-6):
+        """Deactivate active columns that were unused for MAX_AGE iterations
+        and have reduced cost above THRESHOLD, and reactivate inactive
+        columns with negative reduced cost.
+        VALUES and DUALS are the primal and dual values of the last solve.
+        Returns the numbers of deactivated and reactivated columns."""
+        inactive = [name for name in self.columns if name not in self.active]
+        deactivated = 0
+        for name in list(self.active):
+            if values[name] > eps:
+                self.age[name] = 0
+                continue
+            self.age[name] += 1
+            if self.age[name] >= max_age and \
+               self.reduced_cost(name, duals) > threshold:
+                self.deactivate(name)
+                deactivated += 1
+        reactivated = 0
+        for name in inactive:
+            if self.reduced_cost(name, duals) < -eps:
+                self.activate(name)
+                reactivated += 1
+        return deactivated, reactivated
+
+    def solve(self):
+        """Solve the LP relaxation of the master.
+        Returns the objective value, the values of the active columns and the
+        duals by row name."""
+        self.cpx.solve()
+        names = [t.name for t in self.templates]
+        duals = self.cpx.solution.get_dual_values(names)
+        active = list(self.active)
+        values = self.cpx.solution.get_values(active)
+        return self.cpx.solution.get_objective_value(), \
+            dict(zip(active, values)), dict(zip(names, duals))
+
+    def solve_integer(self):
+        """Solve the master as integer program over all columns in the pool."""
+        for name in self.columns:
+            if name not in self.active:
+                self.activate(name)
+        self.cpx.variables.set_types([(name, self.cpx.variables.type.integer)
+                                      for name in self.columns])
+        self.cpx.solve()
+        values = self.cpx.solution.get_values(list(self.columns))
+        return self.cpx.solution.get_objective_value(), \
+            dict(zip(self.columns, values))
+
+
+def knapsack(width, sizes, profits):
+    """Solve the integer knapsack problem max sum profits[i] * a[i] subject
+    to sum sizes[i] * a[i] <= width by dynamic programming.
+    Returns the optimal value and the optimal a as dictionary."""
+    best = [0.0] * (width + 1)
+    choice = [None] * (width + 1)
+    for c in range(1, width + 1):
+        best[c] = best[c - 1]
+        choice[c] = None
+        for i, s in enumerate(sizes):
+            if s <= c and best[c - s] + profits[i] > best[c] + 1e-12:
+                best[c] = best[c - s] + profits[i]
+                choice[c] = i
+    pattern = dict()
+    c = width
+    while c > 0:
+        i = choice[c]
+        if i is None:
+            c -= 1
+        else:
+            pattern[i] = pattern.get(i, 0) + 1
+            c -= sizes[i]
+    return best[width], pattern
+
+
+class Stabilization(object):
+    """Dual stabilization for column generation.
+
+    METHOD is one of
+    - 'none': no stabilization,
+    - 'smoothing': price with a convex combination ALPHA * center +
+      (1 - ALPHA) * duals of the stability center (the duals that gave the
+      best Lagrangian bound so far) and the current duals (Wentges). If this
+      does not produce an improving column (a misprice), price again with
+      the current duals,
+    - 'boxstep': restrict the duals to a box of radius DELTA around the
+      stability center by adding artificial columns to the master (see
+      _install_box() for why the lower side of the box is soft),
+    - 'piecewise': like 'boxstep', but leaving the box is penalized instead
+      of forbidden, by bounding the artificial columns by EPSILON
+      (du Merle et al.).
+    For the box methods the box is shrunk and recentered whenever pricing
+    finds no column but artificial columns are still used.
+    """
+
+    def __init__(self, method='none', alpha=0.5, delta=0.1, epsilon=0.1,
+                 min_delta=1e-4):
+        self.method = method
+        self.alpha = alpha
+        self.delta = delta
+        self.epsilon = epsilon
+        self.min_delta = min_delta
+        self.center = None
+        self.best_bound = None
+        self.master = None
+
+    def is_box(self):
+        return self.method in ('boxstep', 'piecewise')
+
+    def setup(self, master):
+        """Add the artificial columns for box methods to MASTER."""
+        self.master = master
+        if not self.is_box():
+            return
+        ub = cplex.infinity if self.method == 'boxstep' else self.epsilon
+        for t in master.templates:
+            # Column with coefficient +1 bounds the dual from above, column
+            # with coefficient -1 bounds it from below. Costs are set by
+            # _install_box(), until then the columns are disabled.
+            for name, coef in (('stab+_' + t.name, 1.0), ('stab-_' + t.name, -1.0)):
+                master.cpx.variables.add(obj=[0.0], lb=[0.0], ub=[0.0],
+                                         names=[name],
+                                         columns=[cplex.SparsePair(ind=[t.name],
+                                                                   val=[coef])])
+        self.ub = ub
+
+    def _artificials(self):
+        return [prefix + t.name for t in self.master.templates
+                for prefix in ('stab+_', 'stab-_')]
+
+    def _install_box(self):
+        cpx = self.master.cpx
+        obj = list()
+        ub = list()
+        for t in self.master.templates:
+            c = self.center[t.name]
+            obj.append(('stab+_' + t.name, c + self.delta))
+            obj.append(('stab-_' + t.name, -max(0.0, c - self.delta)))
+            ub.append(('stab+_' + t.name, self.ub))
+            # Duals of >= rows are nonnegative anyway, so the lower bound
+            # is only needed if it is positive. It is always only penalized:
+            # a hard lower bound can make the dual infeasible (and the
+            # master unbounded) once columns were added that price out
+            # at the center.
+            ub.append(('stab-_' + t.name,
+                       self.epsilon if c - self.delta > 0 else 0.0))
+        cpx.objective.set_linear(obj)
+        cpx.variables.set_upper_bounds(ub)
+
+    def disable(self):
+        """Remove the effect of stabilization from the master."""
+        if self.is_box():
+            self.master.cpx.variables.set_upper_bounds(
+                [(name, 0.0) for name in self._artificials()])
+        self.method = 'none'
+
+    def pricing_duals(self, duals):
+        if self.method == 'smoothing' and self.center is not None:
+            return {k: self.alpha * self.center[k] + (1.0 - self.alpha) * d
+                    for k, d in duals.items()}
+        return duals
+
+    def update(self, duals, bound):
+        """Record the Lagrangian BOUND obtained with DUALS. Moves the
+        stability center if the bound improved."""
+        if self.best_bound is None or bound > self.best_bound:
+            self.best_bound = bound
+            self.center = dict(duals)
+            if self.is_box():
+                self._install_box()
+
+    def artificial_value(self):
+        if not self.is_box():
+            return 0.0
+        return sum(self.master.cpx.solution.get_values(self._artificials()))
+
+    def recenter(self, duals):
+        """Called if pricing found no column but artificial columns are
+        used. Shrinks the box around the current duals."""
+        self.delta *= 0.5
+        self.epsilon *= 0.5
+        if self.delta < self.min_delta:
+            self.disable()
+            return
+        if self.method == 'piecewise':
+            self.ub = self.epsilon
+        self.center = dict(duals)
+        self._install_box()
+
+    def describe(self):
+        if self.is_box():
+            return '%s delta=%g' % (self.method, self.delta)
+        return self.method
+
+
+def lagrangian_bound(demands, duals, value):
+    """Lower bound for the cutting stock LP from the duals DUALS (a list)
+    and the optimal pricing value VALUE: DUALS / max(1, VALUE) is dual
+    feasible."""
+    return sum(d * p for d, p in zip(demands, duals)) / max(1.0, value)
+
+
+def cutting_stock(width, sizes, demands, stabilization=None,
+                  max_iterations=1000, eps=1e-6):
+    master = Master()
+    items = range(len(sizes))
+    rows = ['demand_%d' % i for i in items]
+    for i in items:
+        master.add_row(RowTemplate(rows[i], 'G', demands[i],
+                                   lambda column, i=i: column.get(i, 0)))
+    # Initial columns: one homogeneous pattern per item.
+    for i in items:
+        master.add_column('p%d' % len(master.columns),
+                          {i: width // sizes[i]}, 1.0)
+    stab = stabilization if stabilization is not None else Stabilization()
+    stab.setup(master)
+
+    for it in range(max_iterations):
+        obj, values, duals = master.solve()
+        deactivated, reactivated = master.manage_pool(values, duals)
+        if reactivated > 0:
+            # Columns from the pool price out, no need to call the pricer.
+            print('Iteration %d: master %f, %d columns deactivated, '
+                  '%d reactivated' % (it, obj, deactivated, reactivated))
+            continue
+        pduals = stab.pricing_duals(duals)
+        value, pattern = knapsack(width, sizes, [pduals[r] for r in rows])
+        stab.update(pduals, lagrangian_bound(demands, [pduals[r] for r in rows],
+                                             value))
+        # Reduced cost of the new column with respect to the current duals.
+        rc = 1.0 - sum(n * duals[rows[i]] for i, n in pattern.items())
+        mispriced = pduals is not duals and rc >= -eps
+        if mispriced:
+            value, pattern = knapsack(width, sizes, [duals[r] for r in rows])
+            stab.update(duals, lagrangian_bound(demands,
+                                                [duals[r] for r in rows], value))
+            rc = 1.0 - value
+        print('Iteration %d: master %f, bound %f, reduced cost %f%s, '
+              '%d/%d active (%d deactivated), %s' %
+              (it, obj, stab.best_bound, rc, ' (misprice)' if mispriced else '',
+               len(master.active), len(master.columns), deactivated,
+               stab.describe()))
+        if rc >= -eps:
+            if stab.artificial_value() > eps:
+                stab.recenter(duals)
+                continue
+            break
+        master.add_column('p%d' % len(master.columns), pattern, 1.0)
+
+    stab.disable()
+    obj, values = master.solve_integer()
+    print('Integer solution with %d rolls:' % round(obj))
+    for name, x in values.items():
+        if x > 0.5:
+            column = master.columns[name][0]
+            print('  %d x %s' % (round(x), ' + '.join('%d*%d' % (n, sizes[i])
+                                                     for i, n in column.items())))
+    return obj
+
+
+if __name__ == "__main__":
+    method = sys.argv[1] if len(sys.argv) > 1 else 'none'
+    cutting_stock(110, [20, 45, 50, 55, 75], [48, 35, 24, 10, 8],
+                  Stabilization(method))
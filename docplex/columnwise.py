@@ -0,0 +1,110 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to build a model column by column.
+#
+# Set partitioning, set covering and column generation models are naturally
+# produced one column at a time: a column (a crew pairing, a route, a
+# pattern) knows its cost and which rows it covers. Building such a model
+# row by row would require collecting the columns of each row first.
+#
+# With the CPLEX Python API the rows are created first with empty left-hand
+# sides. Each variable is then added together with its objective
+# coefficient and its nonzeros in the existing rows. ColumnBuilder buffers
+# columns and adds them in batches, which is much faster than adding them
+# one by one for large models.
+#
+# The example is a small crew pairing problem: each flight must be covered
+# by exactly one pairing, where a pairing is a sequence of up to three
+# flights in which each flight departs where and after the previous one
+# arrives.
+import cplex
+
+
+class ColumnBuilder(object):
+    """Builds a model column by column."""
+
+    def __init__(self, cpx, batch=10000):
+        self.cpx = cpx
+        self.batch = batch
+        self.pending = list()
+
+    def row(self, name, sense, rhs):
+        """Add a row with empty left-hand side. SENSE is 'L', 'G' or 'E'."""
+        self.cpx.linear_constraints.add(senses=[sense], rhs=[rhs],
+                                        names=[name])
+
+    def column(self, name, obj, coefs, lb=0.0, ub=cplex.infinity, type='C'):
+        """Add a variable with objective coefficient OBJ. COEFS maps row
+        names to the coefficients of the variable in these rows."""
+        self.pending.append((name, obj, coefs, lb, ub, type))
+        if len(self.pending) >= self.batch:
+            self.flush()
+
+    def flush(self):
+        """Add all buffered columns to the model."""
+        if len(self.pending) == 0:
+            return
+        names, obj, cols, lb, ub, types = zip(*[
+            (name, o, cplex.SparsePair(ind=list(coefs.keys()),
+                                       val=list(coefs.values())), l, u, t)
+            for name, o, coefs, l, u, t in self.pending])
+        self.pending = list()
+        if all(t == 'C' for t in types):
+            types = []
+        self.cpx.variables.add(obj=list(obj), lb=list(lb), ub=list(ub),
+                               types=''.join(types), names=list(names),
+                               columns=list(cols))
+
+
+def pairings(flights, max_length=3, max_duty=12):
+    """Enumerate all feasible sequences of flights."""
+    def extend(seq):
+        yield seq
+        if len(seq) == max_length:
+            return
+        last = flights[seq[-1]]
+        for f, (origin, dest, dep, arr) in flights.items():
+            if origin == last[1] and dep >= last[3] + 1 and \
+               arr - flights[seq[0]][2] <= max_duty:
+                for s in extend(seq + [f]):
+                    yield s
+    for f in flights:
+        for s in extend([f]):
+            yield s
+
+
+if __name__ == "__main__":
+    # flight: (origin, destination, departure, arrival)
+    flights = {
+        'F1': ('PAR', 'LYS', 6, 7), 'F2': ('LYS', 'NCE', 8, 9),
+        'F3': ('NCE', 'PAR', 10, 12), 'F4': ('PAR', 'NCE', 7, 9),
+        'F5': ('NCE', 'LYS', 10, 11), 'F6': ('LYS', 'PAR', 12, 13),
+        'F7': ('PAR', 'LYS', 14, 15), 'F8': ('LYS', 'PAR', 17, 18),
+    }
+    cpx = cplex.Cplex()
+    cpx.objective.set_sense(cpx.objective.sense.minimize)
+    builder = ColumnBuilder(cpx)
+    for f in flights:
+        builder.row('cover_' + f, 'E', 1.0)
+    for k, seq in enumerate(pairings(flights)):
+        # Fixed cost per crew plus the duty time.
+        cost = 10 + flights[seq[-1]][3] - flights[seq[0]][2]
+        builder.column('p%d' % k, cost, {'cover_' + f: 1.0 for f in seq},
+                       ub=1.0, type='B')
+    builder.flush()
+    print('%d rows, %d columns' % (cpx.linear_constraints.get_num(),
+                                   cpx.variables.get_num()))
+
+    cpx.solve()
+    print('Status: %s' % cpx.solution.get_status_string())
+    print('Cost: %g' % cpx.solution.get_objective_value())
+    for name, x in zip(cpx.variables.get_names(), cpx.solution.get_values()):
+        if x > 0.5:
+            col = cpx.variables.get_cols(name)
+            print('  %s: %s' % (name, ' '.join(
+                cpx.linear_constraints.get_names(i)[6:] for i in col.ind)))
+    cpx.end()
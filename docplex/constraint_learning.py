@@ -0,0 +1,134 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to learn constraints from historical operating data and
+# add them to a DOcplex model.
+#
+# Often the true limits of a process are not known analytically, but records
+# of operating points that ran fine are available. Two simple ways to turn
+# those records into constraints are shown here:
+# - range rules: each quantity must stay within the min/max that was observed,
+# - the convex hull of the observed points, expressed as linear inequalities.
+# The hull is tighter than the range rules and prevents the optimizer from
+# picking combinations that were never observed together.
+#
+# The historical data can be given as a CSV file with two columns (one
+# operating point per line). If no file is given, synthetic data is generated.
+import random
+import sys
+
+from docplex.mp.model import Model
+
+
+def generate_history(n, seed=42):
+    """Sample N feasible operating points of a process whose (unknown) limits
+    are a + b <= 100, 2a + b <= 150, b <= 80, a, b >= 0."""
+    rnd = random.Random(seed)
+    points = list()
+    while len(points) < n:
+        a = rnd.uniform(0, 100)
+        b = rnd.uniform(0, 100)
+        if a + b <= 100 and 2 * a + b <= 150 and b <= 80:
+            points.append((a, b))
+    return points
+
+
+def read_history(filename):
+    """Read operating points from a CSV file with two columns."""
+    points = list()
+    with open(filename, 'r') as f:
+        for line in f:
+            line = line.strip()
+            if len(line) == 0 or line.startswith('#'):
+                continue
+            a, b = line.split(',')[:2]
+            points.append((float(a), float(b)))
+    return points
+
+
+def cross(o, p, q):
+    """Cross product of vectors OP and OQ."""
+    return (p[0] - o[0]) * (q[1] - o[1]) - (p[1] - o[1]) * (q[0] - o[0])
+
+
+def convex_hull(points):
+    """Compute the convex hull of POINTS using Andrew's monotone chain.
+    Returns the hull vertices in counterclockwise order."""
+    pts = sorted(set(points))
+    if len(pts) <= 2:
+        return pts
+    lower = list()
+    for p in pts:
+        while len(lower) >= 2 and cross(lower[-2], lower[-1], p) <= 0:
+            lower.pop()
+        lower.append(p)
+    upper = list()
+    for p in reversed(pts):
+        while len(upper) >= 2 and cross(upper[-2], upper[-1], p) <= 0:
+            upper.pop()
+        upper.append(p)
+    return lower[:-1] + upper[:-1]
+
+
+def add_range_rules(m, x, points):
+    """Add constraints min <= x[k] <= max for each coordinate k."""
+    cts = list()
+    for k in range(len(x)):
+        lo = min(p[k] for p in points)
+        hi = max(p[k] for p in points)
+        cts.append(m.add_range(lo, x[k], hi, 'range_%d' % k))
+    return cts
+
+
+def add_hull_constraints(m, x, points):
+    """Add one constraint per facet of the convex hull of POINTS.
+
+    For consecutive hull vertices p and q in counterclockwise order, the
+    interior of the hull lies to the left of the edge p -> q, that is
+    cross(p, q, x) >= 0. This is linear in x.
+    """
+    hull = convex_hull(points)
+    cts = list()
+    for i, p in enumerate(hull):
+        q = hull[(i + 1) % len(hull)]
+        dx = q[0] - p[0]
+        dy = q[1] - p[1]
+        cts.append(m.add_constraint(dx * x[1] - dy * x[0] >= dx * p[1] - dy * p[0],
+                                    'hull_%d' % i))
+    return cts
+
+
+def solve(points, use_hull):
+    with Model(name='learned') as m:
+        x = [m.continuous_var(name='a'), m.continuous_var(name='b')]
+        m.maximize(30 * x[0] + 20 * x[1])
+        add_range_rules(m, x, points)
+        if use_hull:
+            add_hull_constraints(m, x, points)
+        print('Model with %d learned constraints' % m.number_of_constraints)
+        sol = m.solve()
+        assert sol is not None
+        print('  profit = %f at a = %f, b = %f' %
+              (sol.get_objective_value(), x[0].solution_value,
+               x[1].solution_value))
+        return [v.solution_value for v in x]
+
+
+if __name__ == "__main__":
+    if len(sys.argv) > 1:
+        history = read_history(sys.argv[1])
+    else:
+        history = generate_history(200)
+    print('Learning from %d historical operating points' % len(history))
+
+    # With range rules only the optimizer picks the corner (max a, max b),
+    # which was never observed and may well be infeasible in practice.
+    print('Range rules only:')
+    solve(history, False)
+    # With the convex hull the solution is a combination of operating points
+    # that were actually observed.
+    print('Range rules and convex hull:')
+    solve(history, True)
@@ -0,0 +1,84 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to explain infeasibility of a CP Optimizer model to an
+# end user.
+#
+# Each constraint that may be part of an explanation is given a name and a
+# human readable message. If propagation detects that the model is
+# infeasible, the conflict refiner computes a minimal set of constraints
+# that is infeasible on its own. The names of the constraints in that set are
+# then mapped back to the messages, which produces explanations like
+# "you cannot pin both of these jobs to Monday".
+from docplex.cp.model import CpoModel
+
+Days = ['Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday']
+Hours = 8
+
+# Jobs and their durations in hours.
+Jobs = {'audit': 5, 'backup': 3, 'migration': 6, 'review': 2, 'training': 4}
+
+
+class Explainer(object):
+    """Keeps track of constraints and their messages."""
+
+    def __init__(self, mdl):
+        self.mdl = mdl
+        self.messages = dict()
+
+    def add(self, ct, name, message):
+        """Add CT to the model under NAME with explanation MESSAGE."""
+        ct.set_name(name)
+        self.mdl.add(ct)
+        self.messages[name] = message
+
+    def explain(self, **kwargs):
+        """Check the model and return a list of messages that explain why it
+        is infeasible. Returns None if propagation does not detect
+        infeasibility."""
+        res = self.mdl.propagate(**kwargs)
+        if res.get_solve_status() != 'Infeasible':
+            return None
+        conflict = self.mdl.refine_conflict(**kwargs)
+        messages = list()
+        for ct in conflict.get_all_member_constraints():
+            name = ct.get_name()
+            messages.append(self.messages.get(name, name))
+        return messages
+
+
+def check(pins):
+    """Check whether the jobs can be pinned to days as given in PINS."""
+    mdl = CpoModel(name='jobs')
+    day = {j: mdl.integer_var(0, len(Days) - 1, name=j) for j in Jobs}
+    ex = Explainer(mdl)
+    for d, dname in enumerate(Days):
+        ex.add(mdl.sum(Jobs[j] * (day[j] == d) for j in Jobs) <= Hours,
+               'capacity_%s' % dname,
+               'at most %d hours of work fit on %s' % (Hours, dname))
+    ex.add(day['backup'] < day['migration'], 'backup_first',
+           'the backup must happen before the migration')
+    ex.add(day['review'] > day['audit'], 'review_after_audit',
+           'the review must happen after the audit')
+    for j, dname in pins.items():
+        ex.add(day[j] == Days.index(dname), 'pin_%s' % j,
+               'you pinned %s to %s' % (j, dname))
+
+    print('Pins: %s' % ', '.join('%s on %s' % p for p in pins.items()))
+    messages = ex.explain(LogVerbosity='Quiet')
+    if messages is None:
+        print('  no conflict detected')
+    else:
+        print('  this is not possible because')
+        for m in messages:
+            print('  - %s' % m)
+
+
+if __name__ == "__main__":
+    check({'audit': 'Monday'})
+    check({'audit': 'Monday', 'migration': 'Monday'})
+    check({'migration': 'Monday'})
+    check({'audit': 'Friday'})
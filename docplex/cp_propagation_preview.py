@@ -0,0 +1,97 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to use constraint propagation of CP Optimizer to
+# preview which options remain available after a user pinned some choices.
+#
+# This is the typical pattern of a configuration assistant: the user picks
+# values for some options one at a time and the UI only offers the values
+# for the remaining options that are still compatible with the choices
+# made so far. No search is launched, only propagation, so this is fast
+# enough to be run after every click.
+#
+# Note that propagation is not complete: a value that remains in a domain
+# is not guaranteed to be part of a solution, but a value that is removed
+# certainly is not.
+from docplex.cp.model import CpoModel
+
+# Options and their values.
+Options = {
+    'engine': ['petrol', 'diesel', 'hybrid', 'electric'],
+    'gearbox': ['manual', 'automatic'],
+    'trim': ['base', 'comfort', 'sport'],
+    'wheels': ['16in', '17in', '18in', '19in'],
+}
+
+# Prices of the options (same order as the values above).
+Prices = {
+    'engine': [0, 1500, 4000, 9000],
+    'gearbox': [0, 1800],
+    'trim': [0, 2000, 3500],
+    'wheels': [0, 400, 900, 1500],
+}
+
+
+def build_model(budget):
+    mdl = CpoModel(name='configurator')
+    x = {o: mdl.integer_var(0, len(Options[o]) - 1, name=o) for o in Options}
+    idx = {o: {v: k for k, v in enumerate(Options[o])} for o in Options}
+    # Hybrid and electric engines come with an automatic gearbox.
+    for e in ('hybrid', 'electric'):
+        mdl.add(mdl.if_then(x['engine'] == idx['engine'][e],
+                            x['gearbox'] == idx['gearbox']['automatic']))
+    # Sport trim requires 18in or 19in wheels and is not available with
+    # a diesel engine.
+    mdl.add(mdl.if_then(x['trim'] == idx['trim']['sport'],
+                        x['wheels'] >= idx['wheels']['18in']))
+    mdl.add(mdl.if_then(x['trim'] == idx['trim']['sport'],
+                        x['engine'] != idx['engine']['diesel']))
+    # Base trim only comes with 16in or 17in wheels.
+    mdl.add(mdl.if_then(x['trim'] == idx['trim']['base'],
+                        x['wheels'] <= idx['wheels']['17in']))
+    # Total price of the options must be within budget.
+    mdl.add(mdl.sum(mdl.element(Prices[o], x[o]) for o in Options) <= budget)
+    return mdl, x, idx
+
+
+def expand(domain):
+    """Expand a domain returned by propagation into a list of values.
+    Domains are lists of values and (min, max) intervals."""
+    values = list()
+    for d in domain:
+        if isinstance(d, (tuple, list)):
+            values.extend(range(d[0], d[1] + 1))
+        else:
+            values.append(d)
+    return values
+
+
+def preview(pinned, budget=8000):
+    """Propagate the model with the choices in PINNED (a dictionary that
+    maps options to values) and print the values that remain available."""
+    mdl, x, idx = build_model(budget)
+    for o, v in pinned.items():
+        mdl.add(x[o] == idx[o][v])
+    res = mdl.propagate(LogVerbosity='Quiet')
+    print('Pinned: %s' % ', '.join('%s=%s' % (o, v) for o, v in pinned.items()))
+    if res.get_solve_status() == 'Infeasible':
+        print('  no compatible configuration')
+        return None
+    available = dict()
+    for o in Options:
+        # The value of a variable that is not fixed is its domain.
+        value = res.get_var_solution(x[o]).get_value()
+        domain = value if isinstance(value, (tuple, list)) else [value]
+        available[o] = [Options[o][k] for k in expand(domain)]
+        print('  %-8s %s' % (o, ' '.join(available[o])))
+    return available
+
+
+if __name__ == "__main__":
+    preview({})
+    preview({'trim': 'sport'})
+    preview({'trim': 'sport', 'gearbox': 'manual'})
+    preview({'engine': 'electric', 'trim': 'sport'})
@@ -0,0 +1,90 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to enumerate multiple solutions of a CP Optimizer
+# model, optionally requiring the solutions to be different from each other.
+#
+# Two approaches are shown:
+# - enumerate_solutions() iterates over the solutions found by a single
+#   search, like startNewSearch()/next() in the C++ API. Solutions that are
+#   too close (according to a user supplied distance function) to solutions
+#   already returned are skipped.
+# - diverse_solutions() solves the model repeatedly and after each solution
+#   adds a constraint that requires the next solution to differ from all
+#   previous ones in at least a given number of variables. This produces
+#   diverse solutions much faster if there are many similar solutions, but
+#   each solution requires a new search.
+from docplex.cp.model import CpoModel
+from docplex.cp.solver.solver import CpoSolver
+
+
+def hamming(a, b):
+    """Number of positions in which the value lists A and B differ."""
+    return sum(1 for x, y in zip(a, b) if x != y)
+
+
+def enumerate_solutions(mdl, variables, limit, min_distance=0,
+                        distance=hamming):
+    """Generate up to LIMIT solutions of MDL as lists of values of VARIABLES.
+    A solution is only returned if its distance to all previously returned
+    solutions is at least MIN_DISTANCE."""
+    # Depth first search with a single worker enumerates each solution once.
+    solver = CpoSolver(mdl, SearchType='DepthFirst', Workers=1,
+                       LogVerbosity='Quiet')
+    found = list()
+    try:
+        while len(found) < limit:
+            sol = solver.search_next()
+            if not sol:
+                break
+            values = [sol[v] for v in variables]
+            if all(distance(values, f) >= min_distance for f in found):
+                found.append(values)
+                yield values
+    finally:
+        solver.end_search()
+
+
+def diverse_solutions(mdl, variables, limit, min_distance):
+    """Generate up to LIMIT solutions of MDL as lists of values of VARIABLES
+    such that each pair of solutions differs in at least MIN_DISTANCE
+    variables. Constraints added to MDL are removed again at the end."""
+    added = list()
+    try:
+        for k in range(limit):
+            sol = mdl.solve(LogVerbosity='Quiet')
+            if not sol:
+                break
+            values = [sol[v] for v in variables]
+            yield values
+            ct = mdl.sum(v != val for v, val in zip(variables, values)) >= min_distance
+            mdl.add(ct)
+            added.append(ct)
+    finally:
+        for ct in added:
+            mdl.remove(ct)
+
+
+def build_queens(n):
+    mdl = CpoModel(name='queens')
+    x = mdl.integer_var_list(n, 0, n - 1, name='x')
+    mdl.add(mdl.all_diff(x))
+    mdl.add(mdl.all_diff(x[i] + i for i in range(n)))
+    mdl.add(mdl.all_diff(x[i] - i for i in range(n)))
+    return mdl, x
+
+
+if __name__ == "__main__":
+    mdl, x = build_queens(8)
+    print('First 5 solutions:')
+    for values in enumerate_solutions(mdl, x, 5):
+        print('  %s' % values)
+    print('5 solutions that differ in at least 7 rows:')
+    for values in enumerate_solutions(mdl, x, 5, min_distance=7):
+        print('  %s' % values)
+    print('5 diverse solutions by re-solving:')
+    for values in diverse_solutions(mdl, x, 5, 7):
+        print('  %s' % values)
@@ -0,0 +1,249 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to separate constraints in a loop around solve()
+# instead of from a callback.
+#
+# The loop is: solve the model, ask the separator for constraints violated
+# by the solution, add them and repeat. This is slower than separation in a
+# lazy constraint callback, but it is useful if the separation code cannot
+# be made callback-safe (for example because it is not thread-safe or uses
+# another solver).
+#
+# The loop stops if
+# - the separator does not find violated constraints (the solution is
+#   feasible for the full model),
+# - the iteration or time limit is hit,
+# - the objective did not move by more than a tolerance for a number of
+#   iterations (tailing off).
+#
+# Optionally, cuts are managed by a CutPool. The pool
+# - drops cuts that are duplicates of cuts already in the pool (cuts are
+#   compared after normalizing them to <= form with largest coefficient 1),
+# - purges cuts from the model that have not been binding for a number of
+#   iterations, so that long runs do not drown in accumulated cuts,
+# - re-checks purged cuts against each new solution and adds them back if
+#   they are violated again. This is cheaper than calling the separator.
+import math
+import random
+import time
+
+from docplex.mp.model import Model
+
+
+class CutPool(object):
+    """Pool of cuts with deduplication, purging and violation re-checking."""
+
+    def __init__(self, mdl, max_age=5, tol=1e-6, digits=9):
+        self.mdl = mdl
+        self.max_age = max_age
+        self.tol = tol
+        self.digits = digits
+        # Maps normalized keys to cut records. A record is a list
+        # [expr, sense, constraint or None, age] where EXPR SENSE 0 is the
+        # cut and constraint is None if the cut is not in the model.
+        self.cuts = dict()
+
+    def normalize(self, ct):
+        """Return (key, expr, sense) for the linear constraint CT, where
+        EXPR SENSE 0 is equivalent to CT and SENSE is 'LE' or 'EQ'."""
+        expr = ct.get_left_expr() - ct.get_right_expr()
+        sense = ct.sense.name
+        if sense == 'GE':
+            expr = -expr
+            sense = 'LE'
+        terms = [(v.index, coef) for v, coef in expr.iter_terms() if coef != 0]
+        scale = max([abs(c) for _, c in terms] + [1e-12])
+        key = (sense,
+               round(expr.get_constant() / scale, self.digits),
+               tuple(sorted((j, round(c / scale, self.digits))
+                            for j, c in terms)))
+        return key, expr, sense
+
+    def _activate(self, rec):
+        ct = rec[0] <= 0 if rec[1] == 'LE' else rec[0] == 0
+        rec[2] = self.mdl.add_constraint(ct)
+        rec[3] = 0
+
+    def add(self, cuts):
+        """Add CUTS to the pool and the model, skipping duplicates.
+        Returns the number of cuts added."""
+        added = 0
+        for ct in cuts:
+            key, expr, sense = self.normalize(ct)
+            if key in self.cuts:
+                rec = self.cuts[key]
+                if rec[2] is None:
+                    self._activate(rec)
+                    added += 1
+                continue
+            rec = [expr, sense, None, 0]
+            self.cuts[key] = rec
+            self._activate(rec)
+            added += 1
+        return added
+
+    def violation(self, rec, sol):
+        value = sol.get_value(rec[0])
+        return abs(value) if rec[1] == 'EQ' else max(0.0, value)
+
+    def update(self, sol):
+        """Update the age of all cuts in the model from solution SOL and
+        purge cuts that have not been binding for too long.
+        Returns the number of purged cuts."""
+        purged = list()
+        for rec in self.cuts.values():
+            if rec[2] is None:
+                continue
+            if abs(sol.get_value(rec[0])) <= self.tol:
+                rec[3] = 0
+            else:
+                rec[3] += 1
+                if rec[3] > self.max_age:
+                    purged.append(rec)
+        if len(purged) > 0:
+            self.mdl.remove_constraints([rec[2] for rec in purged])
+            for rec in purged:
+                rec[2] = None
+        return len(purged)
+
+    def recheck(self, sol):
+        """Add purged cuts that are violated by SOL back to the model.
+        Returns the number of cuts added back."""
+        count = 0
+        for rec in self.cuts.values():
+            if rec[2] is None and self.violation(rec, sol) > self.tol:
+                self._activate(rec)
+                count += 1
+        return count
+
+    def size(self):
+        """Return the number of cuts in the model and in the pool."""
+        return sum(1 for rec in self.cuts.values() if rec[2] is not None), \
+            len(self.cuts)
+
+
+def cut_loop(mdl, separate, max_iterations=100, time_limit=None,
+             stall_iterations=None, stall_tolerance=1e-6, pool=None,
+             log=True):
+    """Solve MDL in a cutting loop.
+
+    SEPARATE is a function that takes a solution of MDL and returns a list
+    of constraints that are violated by it. Violated constraints are added
+    to MDL, through POOL if that is not None.
+
+    Returns a tuple (solution, status) where status is one of 'optimal'
+    (no violated constraints found and the last solve was not stopped by a
+    limit), 'iterations', 'time', 'stalled' or 'infeasible'. The time limit
+    parameter of MDL is restored on return.
+    """
+    start = time.time()
+    history = list()
+    timelimit = mdl.parameters.timelimit
+    saved_limit = timelimit.get()
+    try:
+        for it in range(max_iterations):
+            if time_limit is not None:
+                remaining = time_limit - (time.time() - start)
+                if remaining <= 0:
+                    return mdl.solution, 'time'
+                timelimit.set(min(remaining, saved_limit))
+            sol = mdl.solve()
+            # A solve that stopped at a limit proves neither infeasibility
+            # nor optimality.
+            limited = mdl.solve_details.has_hit_limit()
+            if sol is None:
+                return None, 'time' if limited else 'infeasible'
+            obj = sol.get_objective_value()
+            if pool is not None:
+                purged = pool.update(sol)
+                readded = pool.recheck(sol)
+                if readded > 0:
+                    # Cuts from the pool are violated, no need to separate.
+                    if log:
+                        print('Iteration %d: objective %f, %d cuts purged, '
+                              '%d re-added from pool' % (it, obj, purged, readded))
+                    history.append(obj)
+                    continue
+            cuts = separate(sol)
+            if log:
+                print('Iteration %d: objective %f, %d violated constraints' %
+                      (it, obj, len(cuts)))
+            if len(cuts) == 0:
+                return sol, 'time' if limited else 'optimal'
+            if pool is not None:
+                pool.add(cuts)
+                if log:
+                    print('  %d cuts in model, %d in pool' % pool.size())
+            else:
+                mdl.add_constraints(cuts)
+            history.append(obj)
+            if stall_iterations is not None and len(history) > stall_iterations:
+                if abs(history[-1] - history[-1 - stall_iterations]) <= \
+                   stall_tolerance * max(1.0, abs(history[-1])):
+                    return sol, 'stalled'
+        return mdl.solution, 'iterations'
+    finally:
+        timelimit.set(saved_limit)
+
+
+def subtours(sol, x, Cities):
+    """Find the subtours in the solution SOL of the TSP model."""
+    succ = dict()
+    for (i, j), v in x.items():
+        if sol.get_value(v) > 0.5:
+            succ.setdefault(i, []).append(j)
+            succ.setdefault(j, []).append(i)
+    tours = list()
+    visited = set()
+    for i in Cities:
+        if i in visited:
+            continue
+        tour = list()
+        stack = [i]
+        while len(stack) > 0:
+            node = stack.pop()
+            if node in visited:
+                continue
+            visited.add(node)
+            tour.append(node)
+            stack.extend(succ.get(node, []))
+        tours.append(tour)
+    return tours
+
+
+if __name__ == "__main__":
+    rnd = random.Random(42)
+    n = 30
+    Cities = range(n)
+    pos = [(rnd.uniform(0, 100), rnd.uniform(0, 100)) for i in Cities]
+    Edges = [(i, j) for i in Cities for j in Cities if i < j]
+    dist = {(i, j): math.hypot(pos[i][0] - pos[j][0], pos[i][1] - pos[j][1])
+            for (i, j) in Edges}
+
+    with Model(name='tsp') as m:
+        x = m.binary_var_dict(Edges, name='x')
+        m.minimize(m.sum(dist[e] * x[e] for e in Edges))
+        for j in Cities:
+            m.add_constraint(m.sum(x[e] for e in Edges if j in e) == 2)
+
+        def separate(sol):
+            # Eliminate every subtour that does not visit all cities.
+            cuts = list()
+            tours = subtours(sol, x, Cities)
+            if len(tours) > 1:
+                for tour in tours:
+                    nodes = set(tour)
+                    cuts.append(m.sum(x[e] for e in Edges
+                                      if e[0] in nodes and e[1] in nodes)
+                                <= len(nodes) - 1)
+            return cuts
+
+        sol, status = cut_loop(m, separate, max_iterations=50,
+                               time_limit=60, pool=CutPool(m))
+        print('Loop finished with status %s' % status)
+        if sol is not None:
+            print('Tour length: %f' % sol.get_objective_value())
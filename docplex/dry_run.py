@@ -0,0 +1,66 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to check model building code without solving, for
+# example in CI on machines without a CPLEX license.
+#
+# A dry run builds the model, then
+# - prints its statistics,
+# - checks it against size limits (by default the limits of the CPLEX
+#   Community Edition, 1000 variables and 1000 constraints),
+# - runs the validation rules of model_rules.py,
+# - propagates bounds with bound_propagation.py to find rows that can never
+#   be satisfied,
+# - optionally exports the model to an LP file.
+# Building, validating, propagating and exporting only need the docplex
+# package: the rules run on the model in memory and docplex writes LP files
+# itself. This is different from running model_rules.py on model files,
+# which needs the CPLEX runtime to read them.
+#
+# The model is built by a function FUNCTION in module MODULE that takes no
+# arguments and returns a model.
+#
+# Usage: python dry_run.py <module> <function> [<file.lp>]
+import importlib
+import sys
+
+from bound_propagation import propagate
+from model_rules import validate
+
+COMMUNITY_LIMITS = {'variables': 1000, 'constraints': 1000}
+
+
+def dry_run(mdl, limits=COMMUNITY_LIMITS, export=None):
+    """Check MDL without solving it. Returns a list of problems."""
+    problems = list()
+    mdl.print_information()
+    sizes = {'variables': mdl.number_of_variables,
+             'constraints': mdl.number_of_constraints}
+    for what, limit in limits.items():
+        if sizes[what] > limit:
+            problems.append('%d %s, limit is %d' % (sizes[what], what, limit))
+    for name, msg in validate(mdl):
+        problems.append('[%s] %s' % (name, msg))
+    bounds, infeasible = propagate(mdl, apply=False)
+    for name in infeasible:
+        problems.append('%s can never be satisfied' % name)
+    if export is not None:
+        mdl.export_as_lp(export)
+    return problems
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 3:
+        print('Usage: python dry_run.py <module> <function> [<file.lp>]')
+        sys.exit(2)
+    build = getattr(importlib.import_module(sys.argv[1]), sys.argv[2])
+    mdl = build()
+    problems = dry_run(mdl, export=sys.argv[3] if len(sys.argv) > 3 else None)
+    for p in problems:
+        print(p)
+    print('%d problems found' % len(problems))
+    mdl.end()
+    sys.exit(1 if len(problems) > 0 else 0)
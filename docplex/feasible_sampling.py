@@ -0,0 +1,181 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to sample feasible points of a model, for example as
+# input for a Monte Carlo simulation of how robust plans are.
+#
+# Two samplers are shown:
+# - random_objectives() solves the model with random objective functions
+#   and stops each solve at the first solution. This works for MIPs, but
+#   the points are vertices of the feasible region (or close to them) and
+#   not spread uniformly.
+# - hit_and_run() samples the feasible region of the LP relaxation
+#   approximately uniformly. Starting from an interior point, it repeatedly
+#   picks a random direction, computes how far it can move in both
+#   directions without leaving the region and jumps to a uniformly chosen
+#   point on that segment. Equality constraints and fixed variables are
+#   handled by projecting the directions onto their null space. Other
+#   inequalities that are tight at every feasible point leave no segment
+#   to move on; this is reported as an error. The interior starting point
+#   is the average of a few points from random_objectives(). Consecutive
+#   points are correlated, so only every THIN-th point is returned.
+# Both samplers need a bounded feasible region. hit_and_run() uses dense
+# matrices and is meant for models with up to a few thousand variables.
+import random
+
+import numpy as np
+
+from bound_propagation import extract_rows
+from docplex.mp.model import Model
+from docplex.mp.relax_linear import LinearRelaxer
+
+
+def random_objectives(mdl, n, seed=None, **kwargs):
+    """Return (names, samples): up to N feasible points of MDL as lists of
+    variable values, in the order of NAMES."""
+    rnd = random.Random(seed)
+    objective = mdl.objective_expr
+    sense = mdl.objective_sense
+    variables = list(mdl.iter_variables())
+    samples = list()
+    limit = mdl.parameters.mip.limits.solutions
+    old = limit.get()
+    try:
+        limit.set(1)
+        for k in range(n):
+            mdl.minimize(mdl.sum(rnd.uniform(-1, 1) * v for v in variables))
+            sol = mdl.solve(**kwargs)
+            if sol is None:
+                break
+            samples.append([sol.get_value(v) for v in variables])
+    finally:
+        limit.set(old)
+        mdl.set_objective(sense, objective)
+    return [v.name for v in variables], samples
+
+
+def hit_and_run(mdl, n, thin=10, seed=None, tol=1e-9):
+    """Return (names, samples): N points sampled from the feasible region
+    of the LP relaxation of MDL."""
+    if mdl.number_of_integer_vars + mdl.number_of_binary_vars == 0:
+        return _hit_and_run(mdl, n, thin, seed, tol)
+    relaxed = LinearRelaxer.make_relaxed_model(mdl)
+    try:
+        return _hit_and_run(relaxed, n, thin, seed, tol)
+    finally:
+        relaxed.end()
+
+
+def _hit_and_run(mdl, n, thin, seed, tol):
+    variables = list(mdl.iter_variables())
+    pos = {v: k for k, v in enumerate(variables)}
+    dim = len(variables)
+    rnd = np.random.RandomState(seed)
+
+    # Rows as A x <= b and E x = f.
+    A, b, E, f = list(), list(), list(), list()
+    for name, terms, sense, rhs in extract_rows(mdl):
+        row = np.zeros(dim)
+        for v, c in terms:
+            row[pos[v]] += c
+        if sense == 'EQ':
+            E.append(row)
+            f.append(rhs)
+        elif sense == 'LE':
+            A.append(row)
+            b.append(rhs)
+        else:
+            A.append(-row)
+            b.append(-rhs)
+    lb = np.array([v.lb if v.lb > -mdl.infinity else -np.inf for v in variables])
+    ub = np.array([v.ub if v.ub < mdl.infinity else np.inf for v in variables])
+    # Fixed variables are equalities. As a pair of bounds they would leave
+    # no room to move.
+    for j in np.nonzero(lb == ub)[0]:
+        row = np.zeros(dim)
+        row[j] = 1.0
+        E.append(row)
+        f.append(lb[j])
+        lb[j], ub[j] = -np.inf, np.inf
+    # Bounds are rows as well.
+    A = np.vstack(A + [np.eye(dim), -np.eye(dim)])
+    b = np.concatenate([np.array(b), ub, -lb])
+    finite = np.isfinite(b)
+    A, b = A[finite], b[finite]
+    if len(E) > 0:
+        E = np.vstack(E)
+        projection = np.eye(dim) - np.linalg.pinv(E).dot(E)
+        if np.linalg.norm(projection) <= tol:
+            # All directions are projected to zero: the equality
+            # constraints leave no freedom to move.
+            raise Exception('Equality constraints fix all variables')
+    else:
+        projection = None
+
+    names, vertices = random_objectives(mdl, min(2 * dim, 20),
+                                        seed=rnd.randint(1 << 30))
+    if len(vertices) == 0:
+        raise Exception('No feasible point found')
+    x = np.mean(np.array(vertices), axis=0)
+
+    samples = list()
+    stuck = 0
+    while len(samples) < n:
+        for k in range(thin):
+            d = rnd.normal(size=dim)
+            if projection is not None:
+                d = projection.dot(d)
+            norm = np.linalg.norm(d)
+            if norm <= tol:
+                continue
+            d /= norm
+            slack = np.maximum(b - A.dot(x), 0.0)
+            ad = A.dot(d)
+            up = ad > tol
+            down = ad < -tol
+            if not up.any() or not down.any():
+                raise Exception('Feasible region is unbounded')
+            tmax = np.min(slack[up] / ad[up])
+            tmin = np.max(slack[down] / ad[down])
+            if tmax - tmin <= tol:
+                # Inequalities that are tight at every feasible point (like
+                # x + y <= 1 and x + y >= 1) block all directions.
+                stuck += 1
+                if stuck >= 100:
+                    raise Exception('The chain cannot move: state implicit '
+                                    'equalities as equality constraints')
+                continue
+            stuck = 0
+            x = x + rnd.uniform(tmin, tmax) * d
+        samples.append(list(x))
+    return names, samples
+
+
+if __name__ == "__main__":
+    Products = ['chairs', 'tables', 'desks']
+    profit = [45, 80, 110]
+    hours = [[1, 3, 4], [2, 2, 3]]
+    capacity = [240, 200]
+
+    with Model(name='plan') as m:
+        x = m.continuous_var_dict(Products, ub=80, name='make')
+        for r in range(2):
+            m.add_constraint(m.sum(hours[r][k] * x[p]
+                                   for k, p in enumerate(Products))
+                             <= capacity[r])
+        # Contract: at least 20 desks and tables together.
+        m.add_constraint(x['tables'] + x['desks'] >= 20)
+        m.maximize(m.sum(profit[k] * x[p] for k, p in enumerate(Products)))
+
+        names, samples = hit_and_run(m, 1000, seed=1)
+        # Simulate the profit of each plan under uncertain prices.
+        rnd = np.random.RandomState(2)
+        profits = [sum(rnd.normal(profit[k], 0.2 * profit[k]) * s[k]
+                       for k in range(len(Products))) for s in samples]
+        print('Sampled %d feasible plans' % len(samples))
+        print('Mean of %s: %s' % (names, np.mean(np.array(samples), axis=0)))
+        print('Simulated profit: 5%% quantile %.0f, median %.0f, '
+              '95%% quantile %.0f' % tuple(np.percentile(profits, [5, 50, 95])))
@@ -0,0 +1,152 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to turn forecasts with uncertainty bands into model
+# parameters and constraints.
+#
+# A forecast gives, for each period, a point estimate and some quantiles,
+# for example the 10% and 90% quantiles of the demand. read_forecasts()
+# reads them from a CSV file into a table of Bands. The spread of each band
+# is converted to a standard deviation assuming normally distributed
+# errors, so that other quantiles can be interpolated.
+#
+# add_band_constraints() adds expr[t] >= demand[t] for each period t, where
+# the demand is taken from the bands in one of three ways:
+# - 'point': the point estimate (the plan ignores the uncertainty),
+# - 'robust': the quantile at LEVEL of each period. With cumulative
+#   constraints, the quantiles are summed, which assumes that the worst
+#   case happens in every period,
+# - 'chance': the constraint holds with probability LEVEL. Errors of
+#   different periods are assumed independent, so the cumulative demand up
+#   to t has mean sum(point) and standard deviation sqrt(sum(sigma^2)).
+#   This is less conservative than 'robust' and still linear, since the
+#   right-hand sides are constants.
+#
+# Usage: python forecast_bounds.py [forecast file]
+#        The CSV file has a header 'period,point,<level>,<level>,...' with
+#        quantile levels such as 0.1 and 0.9, and one line per period.
+#        If no file is given, a built-in forecast is used.
+import csv
+import math
+import sys
+from collections import namedtuple
+from statistics import NormalDist
+
+from docplex.mp.model import Model
+
+Band = namedtuple('Band', ['point', 'quantiles', 'sigma'])
+
+
+def make_band(point, quantiles):
+    """Create a Band from a point estimate and a dictionary mapping
+    quantile levels to values. The standard deviation is estimated from the
+    lowest and highest quantile."""
+    if len(quantiles) < 2:
+        return Band(point, dict(quantiles), 0.0)
+    z = NormalDist().inv_cdf
+    lo, hi = min(quantiles), max(quantiles)
+    sigma = (quantiles[hi] - quantiles[lo]) / (z(hi) - z(lo))
+    return Band(point, dict(quantiles), max(sigma, 0.0))
+
+
+def read_forecasts(filename):
+    """Read a forecast CSV file into a list of Bands, one per period in the
+    order of the file."""
+    bands = list()
+    with open(filename, 'r', newline='') as f:
+        reader = csv.reader(f)
+        header = next(reader)
+        levels = [float(h) for h in header[2:]]
+        for row in reader:
+            if len(row) == 0:
+                continue
+            bands.append(make_band(float(row[1]),
+                                   {p: float(v) for p, v in
+                                    zip(levels, row[2:])}))
+    return bands
+
+
+def quantile(band, level):
+    """Return the quantile at LEVEL of BAND: the forecast value if the level
+    was forecast, otherwise the normal approximation."""
+    if level in band.quantiles:
+        return band.quantiles[level]
+    return band.point + NormalDist().inv_cdf(level) * band.sigma
+
+
+def demand_table(bands, mode='point', level=0.9, cumulative=False):
+    """Return the right-hand sides for add_band_constraints() as a list with
+    one value per period."""
+    if mode == 'point':
+        values = [b.point for b in bands]
+    elif mode == 'robust':
+        values = [quantile(b, level) for b in bands]
+    elif mode == 'chance':
+        z = NormalDist().inv_cdf(level)
+        if not cumulative:
+            return [b.point + z * b.sigma for b in bands]
+        result = list()
+        mean = var = 0.0
+        for b in bands:
+            mean += b.point
+            var += b.sigma ** 2
+            result.append(mean + z * math.sqrt(var))
+        return result
+    else:
+        raise Exception('Unknown mode %s' % mode)
+    if cumulative:
+        total = 0.0
+        result = list()
+        for v in values:
+            total += v
+            result.append(total)
+        return result
+    return values
+
+
+def add_band_constraints(mdl, exprs, bands, mode='point', level=0.9,
+                         cumulative=False, name='demand'):
+    """Add exprs[t] >= demand[t] to MDL for each period t. With CUMULATIVE,
+    exprs[t] is compared with the demand of the periods up to t."""
+    rhs = demand_table(bands, mode, level, cumulative)
+    return mdl.add_constraints([e >= r for e, r in zip(exprs, rhs)],
+                               ['%s_%d' % (name, t) for t in range(len(rhs))])
+
+
+def plan(bands, mode, level, capacity=140, cost=10, holding=1):
+    """Plan production for the forecast BANDS. Demand may be produced in
+    advance and stored. Returns (production, cost) or None."""
+    periods = range(len(bands))
+    with Model(name='production_%s' % mode) as mdl:
+        x = mdl.continuous_var_list(periods, ub=capacity, name='make')
+        cum = [mdl.sum(x[s] for s in range(t + 1)) for t in periods]
+        add_band_constraints(mdl, cum, bands, mode, level, cumulative=True)
+        # Stock at the end of a period is valued against the point forecast.
+        expected = demand_table(bands, 'point', cumulative=True)
+        mdl.minimize(cost * mdl.sum(x) +
+                     holding * mdl.sum(cum[t] - expected[t] for t in periods))
+        sol = mdl.solve()
+        if sol is None:
+            return None
+        return [x[t].solution_value for t in periods], sol.objective_value
+
+
+if __name__ == "__main__":
+    if len(sys.argv) > 1:
+        forecasts = read_forecasts(sys.argv[1])
+    else:
+        forecasts = [make_band(p, {0.1: p - d, 0.9: p + d})
+                     for p, d in [(80, 10), (100, 15), (90, 20), (130, 25),
+                                  (110, 30), (120, 35)]]
+    for mode in ('point', 'robust', 'chance'):
+        result = plan(forecasts, mode, 0.9)
+        if result is None:
+            print('%-6s: infeasible' % mode)
+        else:
+            production, total = result
+            print('%-6s: produce %s, total %.0f, cost %.0f' %
+                  (mode, ' '.join('%.0f' % p for p in production),
+                   sum(production), total))
@@ -0,0 +1,89 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to find out whether a model for which CPLEX reports
+# "infeasible or unbounded" is infeasible or unbounded.
+#
+# CPLEX reports this status when presolve detects that the model is
+# infeasible or unbounded without determining which. The status is resolved
+# by solving the model again with the objective replaced by zero: the
+# objective can then not be unbounded, so
+# - if there is no solution, the model is infeasible. The conflict refiner
+#   provides a small set of conflicting constraints and bounds as evidence,
+# - if there is a solution, the model is feasible and thus unbounded. The
+#   feasible point is the evidence. For an LP, the original model is also
+#   solved with presolve turned off, so that the simplex returns an unbounded
+#   direction (a ray) along which the objective improves without limit.
+#
+# Usage: python inf_or_unbd.py <model file>
+import sys
+
+from docplex.mp.conflict_refiner import ConflictRefiner
+from docplex.mp.model_reader import ModelReader
+
+# CPX_STAT_INForUNBD, CPXMIP_INForUNBD
+INF_OR_UNBD = (4, 119)
+
+
+def disambiguate(mdl, tol=1e-9, **kwargs):
+    """Solve MDL and resolve the "infeasible or unbounded" status.
+
+    Returns (solution, status, evidence). STATUS is 'infeasible' or
+    'unbounded' if the status had to be resolved, otherwise the status
+    reported by CPLEX. EVIDENCE is a dictionary with key 'conflict' (names
+    of conflicting constraints) for infeasible models and keys 'point' and
+    possibly 'ray' (name/value dictionaries) for unbounded models."""
+    sol = mdl.solve(**kwargs)
+    if sol is not None or mdl.solve_details.status_code not in INF_OR_UNBD:
+        return sol, mdl.solve_details.status, dict()
+
+    objective = mdl.objective_expr
+    sense = mdl.objective_sense
+    try:
+        mdl.minimize(0)
+        feasible = mdl.solve(**kwargs)
+    finally:
+        mdl.set_objective(sense, objective)
+
+    if feasible is None:
+        conflict = ConflictRefiner().refine_conflict(mdl)
+        return None, 'infeasible', {
+            'conflict': [str(c.name or c.element)
+                         for c in conflict.iter_conflicts()]}
+
+    evidence = {'point': {v.name: feasible.get_value(v)
+                          for v in mdl.iter_variables()
+                          if abs(feasible.get_value(v)) > tol}}
+    if mdl.number_of_integer_vars + mdl.number_of_binary_vars == 0:
+        presolve = mdl.parameters.preprocessing.presolve
+        old = presolve.get()
+        try:
+            presolve.set(0)
+            mdl.solve(**kwargs)
+            cpx = mdl.get_cplex()
+            if cpx.solution.get_status() == cpx.solution.status.unbounded:
+                ray = cpx.solution.advanced.get_ray()
+                names = cpx.variables.get_names()
+                evidence['ray'] = {n: r for n, r in zip(names, ray)
+                                   if abs(r) > tol}
+        finally:
+            presolve.set(old)
+    return None, 'unbounded', evidence
+
+
+if __name__ == "__main__":
+    m = ModelReader.read(sys.argv[1])
+    sol, status, evidence = disambiguate(m)
+    print('Status: %s' % status)
+    if 'conflict' in evidence:
+        print('Conflicting constraints and bounds:')
+        for name in evidence['conflict']:
+            print('  %s' % name)
+    if 'point' in evidence:
+        print('Feasible point (nonzeros): %s' % evidence['point'])
+    if 'ray' in evidence:
+        print('Unbounded direction (nonzeros): %s' % evidence['ray'])
+    m.end()
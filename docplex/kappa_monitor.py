@@ -0,0 +1,85 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to monitor the condition numbers (kappa) of the LPs
+# that CPLEX solves during branch and bound.
+#
+# With parameter mip.strategy.kappastats set, CPLEX records for each node LP
+# whether its optimal basis was stable, suspicious, unstable or ill-posed.
+# The distribution is available after the solve as solution quality metrics.
+# This example computes the share of bases that are not stable and, if that
+# share exceeds a threshold, invokes a warning function and optionally
+# re-solves the model with numerical emphasis switched on.
+#
+# Usage: python kappa_monitor.py <model file> [threshold]
+import sys
+
+from docplex.mp.model_reader import ModelReader
+
+
+def kappa_stats(mdl):
+    """Get the kappa statistics of the last solve of MDL as a dictionary."""
+    cpx = mdl.get_cplex()
+    qm = cpx.solution.quality_metric
+    stats = dict()
+    for name, metric in [('stable', qm.kappa_stable),
+                         ('suspicious', qm.kappa_suspicious),
+                         ('unstable', qm.kappa_unstable),
+                         ('illposed', qm.kappa_illposed),
+                         ('max', qm.kappa_max),
+                         ('attention', qm.kappa_attention)]:
+        stats[name] = cpx.solution.get_float_quality(metric)
+    # The share of bases that are not stable. All shares are 0 if no basis
+    # was recorded (for example if presolve solved the model), so this is
+    # not computed as 1 - stable.
+    stats['ill_conditioned'] = stats['suspicious'] + stats['unstable'] + \
+        stats['illposed']
+    return stats
+
+
+def print_warning(stats):
+    print('WARNING: %.1f%% of bases are ill-conditioned (max kappa %g, attention %g)' %
+          (100.0 * stats['ill_conditioned'], stats['max'], stats['attention']))
+
+
+def solve_monitored(mdl, threshold=0.05, on_alert=print_warning,
+                    auto_switch=False, **kwargs):
+    """Solve MDL and check its kappa statistics against THRESHOLD.
+
+    ON_ALERT is called with the statistics if the share of ill-conditioned
+    bases exceeds THRESHOLD. If AUTO_SWITCH is true then the model is solved
+    again with numerical emphasis in that case.
+    Returns a tuple (solution, statistics). Both are None if no solution was
+    found, since kappa statistics are only available after a solve.
+    """
+    # Compute kappa for every node LP (1 would sample).
+    mdl.parameters.mip.strategy.kappastats = 2
+    sol = mdl.solve(**kwargs)
+    if sol is None:
+        return None, None
+    stats = kappa_stats(mdl)
+    for name in ('stable', 'suspicious', 'unstable', 'illposed'):
+        print('  %-10s %6.2f%%' % (name, 100.0 * stats[name]))
+    if stats['ill_conditioned'] > threshold:
+        if on_alert is not None:
+            on_alert(stats)
+        if auto_switch and not mdl.parameters.emphasis.numerical.get():
+            print('Re-solving with numerical emphasis')
+            mdl.parameters.emphasis.numerical = 1
+            sol = mdl.solve(**kwargs)
+            stats = kappa_stats(mdl) if sol is not None else None
+    return sol, stats
+
+
+if __name__ == "__main__":
+    threshold = 0.05
+    if len(sys.argv) > 2:
+        threshold = float(sys.argv[2])
+    m = ModelReader.read(sys.argv[1])
+    sol, stats = solve_monitored(m, threshold, auto_switch=True)
+    if sol is not None:
+        print('Objective: %f' % sol.get_objective_value())
+    m.end()
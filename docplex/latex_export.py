@@ -0,0 +1,224 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to render a (small) DOcplex model as LaTeX or as
+# Markdown math, for example for design documents or papers.
+#
+# Variable names of the form 'x_1_2' are rendered as x_{1,2}, unnamed
+# variables as x_{k} with k the position of the variable in the model.
+# LaTeX special characters in names are escaped. Quadratic objective terms
+# are rendered as well.
+#
+# If collapse is enabled, constraints are grouped into families by the part
+# of their name before the first '_'; the rest of the name gives the indices
+# of the constraint, as in 'demand_3'. A family is rendered in sum notation
+# if all its constraints have the same structure:
+#   \sum_{s} x_{s,i} \geq 10 \quad \forall i
+# To detect this, each index position of each variable is classified as
+# - equal to an index of the constraint name (rendered as i, j, ...),
+# - constant (rendered as is),
+# - varying over the terms of the constraint (summed over, s, t, ...).
+# All terms of a variable must have the same coefficient and all
+# constraints of the family the same right-hand side and number of terms.
+# Families that do not fit, for example because of data-dependent
+# coefficients, are rendered by one representative followed by the number
+# of constraints in the family.
+#
+# Usage: python latex_export.py <model file> [collapse] [markdown]
+import sys
+
+from docplex.mp.constr import LinearConstraint
+from docplex.mp.model_reader import ModelReader
+
+ESCAPE = {'\\': '\\backslash ', '#': '\\#', '$': '\\$', '%': '\\%',
+          '&': '\\&', '{': '\\{', '}': '\\}', '~': '\\sim ', '^': '\\hat{}'}
+CT_INDICES = 'ijkl'
+SUM_INDICES = 'stuvw'
+
+
+def escape(text):
+    return ''.join(ESCAPE.get(c, c) for c in text)
+
+
+def latex_symbol(base, indices):
+    """Render BASE with subscript INDICES (already rendered)."""
+    base = escape(base)
+    if len(base) > 1:
+        base = '\\mathit{%s}' % base
+    if len(indices) == 0:
+        return base
+    return '%s_{%s}' % (base, ','.join(indices))
+
+
+def latex_var(v):
+    """Render 'x_1_2' as 'x_{1,2}'."""
+    if v.name is None:
+        return latex_symbol('x', [str(v.index + 1)])
+    parts = v.name.split('_')
+    return latex_symbol(parts[0], [escape(p) for p in parts[1:]])
+
+
+def latex_coef(coef, term, first):
+    """Render COEF * TERM with its sign."""
+    c = abs(coef)
+    body = term if c == 1 else '%g %s' % (c, term)
+    if first:
+        return body if coef >= 0 else '-' + body
+    return '%s %s' % ('-' if coef < 0 else '+', body)
+
+
+def latex_expr(expr):
+    """Render a linear or quadratic expression."""
+    out = list()
+    if expr.is_quad_expr():
+        for v1, v2, coef in expr.iter_quad_triplets():
+            if v1 is v2:
+                term = '%s^2' % latex_var(v1)
+            else:
+                term = '%s %s' % (latex_var(v1), latex_var(v2))
+            out.append(latex_coef(coef, term, len(out) == 0))
+        expr = expr.get_linear_part()
+    for v, coef in expr.iter_terms():
+        out.append(latex_coef(coef, latex_var(v), len(out) == 0))
+    k = expr.get_constant()
+    if k != 0 or len(out) == 0:
+        out.append('%g' % k if len(out) == 0 else
+                   '%s %g' % ('-' if k < 0 else '+', abs(k)))
+    return ' '.join(out)
+
+
+SENSE = {'LE': '\\leq', 'GE': '\\geq', 'EQ': '='}
+
+
+def latex_constraint(ct):
+    if isinstance(ct, LinearConstraint):
+        left = ct.get_left_expr() - ct.get_right_expr()
+        return '%s %s %g' % (latex_expr(left - left.get_constant()),
+                             SENSE[ct.sense.name], -left.get_constant())
+    # Range constraint
+    return '%g \\leq %s \\leq %g' % (ct.lb, latex_expr(ct.expr), ct.ub)
+
+
+def template(ct):
+    """Return the structure of the linear constraint CT for sum notation,
+    or None if CT does not fit it."""
+    if not isinstance(ct, LinearConstraint) or ct.name is None:
+        return None
+    indices = ct.name.split('_')[1:]
+    expr = ct.get_left_expr() - ct.get_right_expr()
+    byvar = dict()
+    for v, coef in expr.iter_terms():
+        if coef == 0:
+            continue
+        if v.name is None:
+            return None
+        parts = v.name.split('_')
+        byvar.setdefault(parts[0], []).append((parts[1:], coef))
+    terms = list()
+    for base, occurrences in sorted(byvar.items()):
+        coefs = set(c for idx, c in occurrences)
+        sizes = set(len(idx) for idx, c in occurrences)
+        if len(coefs) != 1 or len(sizes) != 1:
+            return None
+        pattern = list()
+        for p in range(sizes.pop()):
+            values = set(idx[p] for idx, c in occurrences)
+            if len(values) > 1:
+                pattern.append(('sum', None))
+            else:
+                value = values.pop()
+                if value in indices:
+                    pattern.append(('index', indices.index(value)))
+                else:
+                    pattern.append(('const', value))
+        terms.append((base, coefs.pop(), tuple(pattern), len(occurrences)))
+    return ct.sense.name, -expr.get_constant(), len(indices), tuple(terms)
+
+
+def latex_family(tmpl):
+    """Render the template TMPL (as returned by template()) in sum
+    notation."""
+    sense, rhs, nindices, terms = tmpl
+    if nindices > len(CT_INDICES):
+        return None
+    out = list()
+    free = iter(SUM_INDICES)
+    for base, coef, pattern, count in terms:
+        sums = list()
+        indices = list()
+        for kind, value in pattern:
+            if kind == 'index':
+                indices.append(CT_INDICES[value])
+            elif kind == 'const':
+                indices.append(escape(value))
+            else:
+                s = next(free, None)
+                if s is None:
+                    return None
+                sums.append(s)
+                indices.append(s)
+        term = latex_symbol(base, indices)
+        if len(sums) > 0:
+            term = '\\sum_{%s} %s' % (','.join(sums), term)
+        out.append(latex_coef(coef, term, len(out) == 0))
+    line = '%s %s %g' % (' '.join(out), SENSE[sense], rhs)
+    if nindices > 0:
+        line += ' \\quad \\forall %s' % ', '.join(CT_INDICES[:nindices])
+    return line
+
+
+def rows(mdl, collapse=False):
+    """Return the lines of the model as LaTeX math, one per objective or
+    constraint (family)."""
+    lines = list()
+    sense = '\\max' if mdl.is_maximized() else '\\min'
+    lines.append('%s \\quad & %s' % (sense, latex_expr(mdl.objective_expr)))
+    lines.append('\\text{s.t.} \\quad &')
+    families = dict()
+    order = list()
+    for ct in mdl.iter_linear_constraints():
+        fam = ct.name.split('_', 1)[0] if collapse and ct.name else id(ct)
+        if fam not in families:
+            families[fam] = list()
+            order.append(fam)
+        families[fam].append(ct)
+    for fam in order:
+        cts = families[fam]
+        if len(cts) == 1:
+            lines.append('& %s' % latex_constraint(cts[0]))
+            continue
+        templates = set(template(ct) for ct in cts)
+        line = None
+        if len(templates) == 1 and None not in templates:
+            line = latex_family(templates.pop())
+        if line is not None:
+            lines.append('& %s \\qquad \\text{(%s, %d constraints)}' %
+                         (line, escape(fam), len(cts)))
+        else:
+            lines.append('& %s \\qquad \\text{(%s, and %d similar)}' %
+                         (latex_constraint(cts[0]), escape(fam),
+                          len(cts) - 1))
+    return lines
+
+
+def to_latex(mdl, collapse=False):
+    """Render MDL as a LaTeX align* environment."""
+    return '\\begin{align*}\n%s\n\\end{align*}' % \
+        ' \\\\\n'.join(rows(mdl, collapse))
+
+
+def to_markdown(mdl, collapse=False):
+    """Render MDL as a Markdown math block, with a heading."""
+    return '### %s\n\n$$\n\\begin{aligned}\n%s\n\\end{aligned}\n$$\n' % \
+        (mdl.name, ' \\\\\n'.join(rows(mdl, collapse)))
+
+
+if __name__ == "__main__":
+    m = ModelReader.read(sys.argv[1])
+    options = sys.argv[2:]
+    render = to_markdown if 'markdown' in options else to_latex
+    print(render(m, collapse='collapse' in options))
+    m.end()
@@ -0,0 +1,127 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to combine CPLEX and CP Optimizer in a logic-based
+# Benders decomposition.
+#
+# Planning and scheduling problems often split naturally: a MIP master
+# assigns jobs to machines (good at costs and capacities), and for each
+# machine a CP subproblem checks whether the assigned jobs can be sequenced
+# within their time windows (good at scheduling). If a machine's subproblem
+# is infeasible, the master gets a combinatorial cut that forbids assigning
+# that set of jobs (or any superset) to the machine again:
+#   sum_{j in S} x[j][m] <= |S| - 1
+# The loop stops when all subproblems are feasible; since the master
+# objective only depends on the assignment, the solution is then optimal.
+#
+# The set S is made as small as possible before the cut is added: jobs are
+# removed one at a time as long as the remaining set is still infeasible.
+# Smaller sets give much stronger cuts. The master also contains a relaxation
+# of the subproblems (the total duration on a machine must fit into the
+# span of the time windows), which keeps the number of iterations low.
+#
+# logic_benders() is generic: it only needs the master model, the
+# assignment variables and a function that checks a machine.
+import random
+
+from docplex.cp.model import CpoModel
+from docplex.mp.model import Model
+
+
+def minimize_conflict(check, machine, jobs):
+    """Remove jobs from JOBS as long as CHECK(machine, jobs) stays
+    infeasible. Returns the reduced list."""
+    conflict = list(jobs)
+    for j in list(jobs):
+        rest = [k for k in conflict if k != j]
+        if len(rest) > 0 and not check(machine, rest):
+            conflict = rest
+    return conflict
+
+
+def logic_benders(master, x, check, max_iterations=100, log=True):
+    """Solve by logic-based Benders decomposition.
+
+    MASTER is the master MIP and X a dictionary that maps (job, machine)
+    to binary assignment variables. CHECK(machine, jobs) returns True if
+    the jobs can be scheduled on the machine.
+    Returns (solution, assignment) or (None, None) if infeasible, where
+    assignment maps machines to lists of jobs."""
+    machines = sorted(set(m for j, m in x))
+    for it in range(max_iterations):
+        sol = master.solve()
+        if sol is None:
+            return None, None
+        assignment = {m: [j for (j, mm), v in x.items()
+                          if mm == m and sol.get_value(v) > 0.5]
+                      for m in machines}
+        cuts = list()
+        for m in machines:
+            if len(assignment[m]) > 0 and not check(m, assignment[m]):
+                conflict = minimize_conflict(check, m, assignment[m])
+                cuts.append(master.sum(x[j, m] for j in conflict)
+                            <= len(conflict) - 1)
+        if log:
+            print('Iteration %d: objective %g, %d cuts' %
+                  (it, sol.get_objective_value(), len(cuts)))
+        if len(cuts) == 0:
+            return sol, assignment
+        master.add_constraints(cuts)
+    raise Exception('Iteration limit reached')
+
+
+def schedule_check(release, deadline, duration):
+    """Return a function that checks with CP Optimizer whether a set of jobs
+    can be sequenced on a machine within their time windows."""
+    def check(machine, jobs):
+        mdl = CpoModel(name='machine_%d' % machine)
+        itv = [mdl.interval_var(start=(release[j], deadline[j]),
+                                end=(release[j], deadline[j]),
+                                size=duration[j][machine],
+                                name='job_%d' % j) for j in jobs]
+        mdl.add(mdl.no_overlap(itv))
+        return bool(mdl.solve(LogVerbosity='Quiet'))
+    return check
+
+
+if __name__ == "__main__":
+    rnd = random.Random(7)
+    nbJobs = 12
+    nbMachines = 3
+    Jobs = range(nbJobs)
+    Machines = range(nbMachines)
+    release = [rnd.randint(0, 20) for j in Jobs]
+    duration = [[rnd.randint(3, 9) * (m + 1) for m in Machines] for j in Jobs]
+    deadline = [release[j] + rnd.randint(15, 30) for j in Jobs]
+    # Faster machines are more expensive.
+    cost = [[(nbMachines - m) * duration[j][m] for m in Machines] for j in Jobs]
+
+    with Model(name='assignment') as master:
+        x = master.binary_var_dict([(j, m) for j in Jobs for m in Machines],
+                                   name='x')
+        for j in Jobs:
+            master.add_constraint(master.sum(x[j, m] for m in Machines) == 1)
+        for j in Jobs:
+            for m in Machines:
+                if release[j] + duration[j][m] > deadline[j]:
+                    master.add_constraint(x[j, m] == 0)
+        # Relaxation of the subproblems.
+        span = max(deadline) - min(release)
+        for m in Machines:
+            master.add_constraint(master.sum(duration[j][m] * x[j, m]
+                                             for j in Jobs) <= span)
+        master.minimize(master.sum(cost[j][m] * x[j, m]
+                                   for j in Jobs for m in Machines))
+
+        sol, assignment = logic_benders(master, x,
+                                        schedule_check(release, deadline,
+                                                       duration))
+        if sol is None:
+            print('Infeasible')
+        else:
+            print('Optimal cost %g' % sol.get_objective_value())
+            for m in Machines:
+                print('Machine %d: jobs %s' % (m, assignment[m]))
@@ -0,0 +1,113 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to export the constraint matrix, bounds and objective
+# of a model for analysis with NumPy and SciPy.
+#
+# Two formats are written:
+# - an .npz bundle. The matrix is stored in the coordinate format used by
+#   scipy.sparse.save_npz(), so scipy.sparse.load_npz() reads it directly.
+#   The same file also contains the vectors obj, lb, ub, rhs, range, the
+#   row senses ('L', 'G', 'E', 'R'), the variable types and the row and
+#   column names, which are read with numpy.load(). The quadratic part of
+#   the objective is stored in coordinate form in q_row, q_col and q_data.
+#   As in CPLEX, the objective is obj'x + 1/2 x'Qx + obj_offset and both
+#   triangles of the symmetric matrix Q are stored,
+# - a MatrixMarket .mtx file with just the matrix, which many other tools
+#   can read as well.
+# The model is read with the CPLEX Python API, so any file format CPLEX
+# reads can be converted. Models with quadratic, indicator or SOS
+# constraints are rejected, since these constraints have no place in the
+# bundle.
+#
+# Usage: python matrix_export.py <model file> <basename>
+import sys
+
+import cplex
+import numpy as np
+
+
+def _check_supported(cpx):
+    if cpx.quadratic_constraints.get_num() > 0 or \
+       cpx.indicator_constraints.get_num() > 0 or \
+       cpx.SOS.get_num() > 0:
+        raise Exception('Only models with linear constraints can be exported')
+
+
+def extract(cpx):
+    """Get the constraint matrix of CPX in coordinate form and the model
+    vectors as a dictionary of NumPy arrays."""
+    _check_supported(cpx)
+    nrows = cpx.linear_constraints.get_num()
+    ncols = cpx.variables.get_num()
+    row, col, data = list(), list(), list()
+    for i, sp in enumerate(cpx.linear_constraints.get_rows() if nrows > 0 else []):
+        row.extend([i] * len(sp.ind))
+        col.extend(sp.ind)
+        data.extend(sp.val)
+    qrow, qcol, qdata = list(), list(), list()
+    if cpx.objective.get_num_quadratic_nonzeros() > 0:
+        for j, sp in enumerate(cpx.objective.get_quadratic()):
+            qrow.extend(sp.ind)
+            qcol.extend([j] * len(sp.ind))
+            qdata.extend(sp.val)
+    if ncols > 0 and cpx.get_problem_type() not in (cpx.problem_type.LP,
+                                                     cpx.problem_type.QP,
+                                                     cpx.problem_type.QCP):
+        types = cpx.variables.get_types()
+    else:
+        types = ['C'] * ncols
+    return {
+        'format': np.array(b'coo'),
+        'shape': np.array([nrows, ncols]),
+        'row': np.array(row, dtype=np.int64),
+        'col': np.array(col, dtype=np.int64),
+        'data': np.array(data, dtype=np.float64),
+        'obj': np.array(cpx.objective.get_linear() if ncols > 0 else []),
+        'objsense': np.array(cpx.objective.get_sense()),
+        'obj_offset': np.array(cpx.objective.get_offset()),
+        'q_row': np.array(qrow, dtype=np.int64),
+        'q_col': np.array(qcol, dtype=np.int64),
+        'q_data': np.array(qdata, dtype=np.float64),
+        'lb': np.array(cpx.variables.get_lower_bounds() if ncols > 0 else []),
+        'ub': np.array(cpx.variables.get_upper_bounds() if ncols > 0 else []),
+        'rhs': np.array(cpx.linear_constraints.get_rhs() if nrows > 0 else []),
+        'range': np.array(cpx.linear_constraints.get_range_values()
+                          if nrows > 0 else []),
+        'sense': np.array(cpx.linear_constraints.get_senses()
+                          if nrows > 0 else []),
+        'types': np.array(types),
+        'row_names': np.array(cpx.linear_constraints.get_names()
+                              if nrows > 0 else []),
+        'col_names': np.array(cpx.variables.get_names() if ncols > 0 else []),
+    }
+
+
+def write_mtx(filename, bundle):
+    """Write the matrix of BUNDLE (as returned by extract()) in MatrixMarket
+    coordinate format. Indices in MatrixMarket files start at 1."""
+    nrows, ncols = bundle['shape']
+    with open(filename, 'w') as f:
+        f.write('%%MatrixMarket matrix coordinate real general\n')
+        f.write('%d %d %d\n' % (nrows, ncols, len(bundle['data'])))
+        for i, j, a in zip(bundle['row'], bundle['col'], bundle['data']):
+            f.write('%d %d %.17g\n' % (i + 1, j + 1, a))
+
+
+if __name__ == "__main__":
+    if len(sys.argv) != 3:
+        print('Usage: python matrix_export.py <model file> <basename>')
+        sys.exit(2)
+    cpx = cplex.Cplex(sys.argv[1])
+    bundle = extract(cpx)
+    cpx.end()
+    np.savez_compressed(sys.argv[2] + '.npz', **bundle)
+    write_mtx(sys.argv[2] + '.mtx', bundle)
+    print('Wrote %d x %d matrix with %d nonzeros to %s.npz and %s.mtx' %
+          (bundle['shape'][0], bundle['shape'][1], len(bundle['data']),
+           sys.argv[2], sys.argv[2]))
+    if len(bundle['q_data']) > 0:
+        print('Objective has %d quadratic nonzeros' % len(bundle['q_data']))
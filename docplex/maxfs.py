@@ -0,0 +1,113 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to find a maximum feasible subsystem (MaxFS) of an
+# infeasible set of linear constraints: the subset of constraints with
+# largest total weight that can be satisfied simultaneously.
+#
+# This complements the conflict refiner: the conflict refiner finds a
+# minimal set of constraints that cannot be satisfied together, MaxFS finds
+# the largest set that can.
+#
+# Each constraint c gets a binary variable z[c] that is 1 if c must hold.
+# Two encodings are supported:
+# - 'indicator': z[c] = 1 -> c, with an indicator constraint,
+# - 'bigm': the constraint is relaxed by M * (1 - z[c]), where M is computed
+#   from the variable bounds (the bounds that can increase expr must be
+#   finite).
+# The constraints are added to the model passed in, and its objective is
+# replaced, so the model cannot be used for anything else afterwards.
+# A greedy heuristic that adds constraints one by one as long as the system
+# stays feasible provides a MIP start.
+from docplex.mp.model import Model
+from docplex.mp.solution import SolveSolution
+
+
+def expr_bounds(expr):
+    """Compute lower and upper bounds of the linear expression EXPR from
+    the bounds of its variables. A bound is -inf or inf if it depends on an
+    infinite variable bound."""
+    inf = float('inf')
+    lo = hi = expr.get_constant()
+    for v, coef in expr.iter_terms():
+        if coef == 0:
+            continue
+        low, up = (v.lb, v.ub) if coef > 0 else (v.ub, v.lb)
+        lo += coef * low if abs(low) < v.model.infinity else -inf
+        hi += coef * up if abs(up) < v.model.infinity else inf
+    return lo, hi
+
+
+def greedy(mdl, constraints):
+    """Add CONSTRAINTS (sorted by decreasing weight) to MDL one at a time and
+    keep each one that does not make the model infeasible. The constraints
+    are removed again at the end. Returns the names of kept constraints."""
+    kept = list()
+    added = list()
+    for expr, weight, name in sorted(constraints, key=lambda c: -c[1]):
+        ct = mdl.add_constraint(expr <= 0)
+        if mdl.solve() is None:
+            mdl.remove_constraint(ct)
+        else:
+            kept.append(name)
+            added.append(ct)
+    mdl.remove_constraints(added)
+    return kept
+
+
+def maxfs(mdl, constraints, encoding='indicator', warmstart=True, **kwargs):
+    """Find a maximum feasible subsystem.
+
+    CONSTRAINTS is a list of (expr, weight, name) triplets, each
+    representing the constraint expr <= 0 (write other constraints in this
+    form). Returns the list of names of the constraints in the subsystem,
+    or None if no solution was found.
+
+    MDL is consumed: its objective is replaced and the z variables and
+    their constraints are added to it. Build a new model to solve it
+    again with other constraints."""
+    mdl.minimize(0)
+    start = greedy(mdl, constraints) if warmstart else None
+    z = dict()
+    for expr, weight, name in constraints:
+        z[name] = mdl.binary_var(name='z_%s' % name)
+        if encoding == 'indicator':
+            mdl.add_indicator(z[name], expr <= 0, 1, name=name)
+        else:
+            lo, hi = expr_bounds(expr)
+            if hi == float('inf'):
+                raise Exception('Constraint %s has no finite big-M: bound '
+                                'the variables that increase it' % name)
+            mdl.add_constraint(expr <= max(hi, 0) * (1 - z[name]), name)
+    mdl.maximize(mdl.sum(weight * z[name] for expr, weight, name in constraints))
+    if start is not None:
+        print('Greedy start satisfies %d constraints' % len(start))
+        mdl.add_mip_start(SolveSolution(mdl, {z[name]: 1 if name in start else 0
+                                              for name in z}))
+    sol = mdl.solve(**kwargs)
+    if sol is None:
+        return None
+    return [name for name in z if sol.get_value(z[name]) > 0.5]
+
+
+if __name__ == "__main__":
+    with Model(name='maxfs') as m:
+        x = m.continuous_var(lb=-10, ub=10, name='x')
+        y = m.continuous_var(lb=-10, ub=10, name='y')
+        # An infeasible system: all constraints are in the form expr <= 0.
+        constraints = [
+            (x + y - 4, 1, 'sum_le_4'),
+            (6 - x - y, 1, 'sum_ge_6'),
+            (x - y - 1, 1, 'diff_le_1'),
+            (2 - x + y, 2, 'diff_ge_2'),
+            (3 - x, 1, 'x_ge_3'),
+            (y - 0.5, 1, 'y_le_half'),
+            (1 - y, 1, 'y_ge_1'),
+        ]
+        kept = maxfs(m, constraints, encoding='bigm')
+        print('Maximum feasible subsystem: %s' % ', '.join(kept))
+        print('Dropped: %s' % ', '.join(name for e, w, name in constraints
+                                        if name not in kept))
@@ -0,0 +1,252 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to embed trained prediction models as constraints of a
+# DOcplex model, so that a learned response function can be optimized over.
+#
+# Supported predictors:
+# - linear models (linear, ridge or lasso regression): y = w x + b is a
+#   linear constraint and is embedded exactly,
+# - decision trees: each leaf gets a binary variable, exactly one leaf is
+#   selected, the splits on the path to the selected leaf must hold for the
+#   input variables, and the prediction is the value of the selected leaf,
+# - gradient-boosted tree ensembles: the prediction is the initial value
+#   plus the learning rate times the sum of the tree predictions.
+# The split conditions of a leaf can be encoded with indicator constraints
+# (z = 1 -> x <= t) or with big-M constraints (x <= t + M (1 - z)). For
+# big-M, M is computed from the bounds of the input variables, so inputs
+# need finite bounds.
+#
+# Trees use the array layout of scikit-learn (children_left,
+# children_right, feature, threshold, value): node k splits on
+# x[feature[k]] <= threshold[k], goes to children_left[k] if the split
+# holds and to children_right[k] otherwise; leaves have children -1.
+# tree_from_sklearn() converts a fitted DecisionTreeRegressor. scikit-learn
+# itself is not needed to build or solve the model.
+#
+# Small feedforward ReLU networks are embedded neuron by neuron. For each
+# neuron, bounds [L, U] of its input a = w h + b are computed from the
+# bounds of the previous layer by interval arithmetic. The output
+# max(0, a) then needs
+# - no binary variable if U <= 0 (the neuron is always off: output 0) or
+#   L >= 0 (always on: output a),
+# - one binary variable z and the big-M constraints out >= a, out <= U z,
+#   out <= a - L (1 - z) otherwise.
+# Tight bounds give small big-M values and fewer binaries, so the bounds of
+# the network inputs should be as tight as possible. Networks are given as
+# a list of (weights, biases) per layer, with ReLU after every layer except
+# the last one; layers_from_onnx() reads such a network from an ONNX file
+# (this needs the onnx package).
+from collections import namedtuple
+
+from docplex.mp.model import Model
+
+Tree = namedtuple('Tree', ['left', 'right', 'feature', 'threshold', 'value'])
+
+
+def tree_from_sklearn(estimator):
+    """Convert a fitted scikit-learn DecisionTreeRegressor (or one of the
+    trees of a GradientBoostingRegressor) to a Tree."""
+    t = estimator.tree_
+    return Tree(list(t.children_left), list(t.children_right),
+                list(t.feature), list(t.threshold),
+                [float(v[0][0]) for v in t.value])
+
+
+def leaves(tree):
+    """Return the leaves of TREE as a list of (node, conditions), where
+    conditions is a list of (feature, is_left, threshold) on the path from
+    the root to the leaf."""
+    result = list()
+    stack = [(0, [])]
+    while len(stack) > 0:
+        k, path = stack.pop()
+        if tree.left[k] < 0:
+            result.append((k, path))
+            continue
+        f, t = tree.feature[k], tree.threshold[k]
+        stack.append((tree.right[k], path + [(f, False, t)]))
+        stack.append((tree.left[k], path + [(f, True, t)]))
+    return result
+
+
+def add_linear_predictor(mdl, x, coef, intercept, name='linear'):
+    """Add y = COEF x + INTERCEPT to MDL and return the variable y."""
+    y = mdl.continuous_var(lb=-mdl.infinity, name=name)
+    mdl.add_constraint(y == mdl.sum(c * xi for c, xi in zip(coef, x)) +
+                       intercept, 'def_%s' % name)
+    return y
+
+
+def _add_condition(mdl, z, xi, is_left, t, encoding, eps, name):
+    if encoding == 'indicator':
+        ct = xi <= t if is_left else xi >= t + eps
+        mdl.add_indicator(z, ct, 1, name=name)
+        return
+    if xi.lb <= -mdl.infinity or xi.ub >= mdl.infinity:
+        raise Exception('Input %s needs finite bounds for big-M' % xi.name)
+    if is_left:
+        if xi.ub > t:
+            mdl.add_constraint(xi <= t + (xi.ub - t) * (1 - z), name)
+    elif xi.lb < t + eps:
+        mdl.add_constraint(xi >= t + eps - (t + eps - xi.lb) * (1 - z), name)
+
+
+def add_tree(mdl, x, tree, encoding='indicator', eps=1e-6, name='tree'):
+    """Add the decision tree TREE with input variables X to MDL and return
+    the variable that holds its prediction. EPS separates the two sides of
+    a split: the right branch requires x >= threshold + EPS."""
+    y = mdl.continuous_var(lb=-mdl.infinity, name=name)
+    z = dict()
+    for k, path in leaves(tree):
+        z[k] = mdl.binary_var(name='%s_leaf%d' % (name, k))
+        for f, is_left, t in path:
+            _add_condition(mdl, z[k], x[f], is_left, t, encoding, eps,
+                           '%s_leaf%d_x%d' % (name, k, f))
+    mdl.add_constraint(mdl.sum(z.values()) == 1, '%s_one_leaf' % name)
+    mdl.add_constraint(y == mdl.sum(tree.value[k] * z[k] for k in z),
+                       'def_%s' % name)
+    return y
+
+
+def add_ensemble(mdl, x, trees, rate, init, encoding='indicator', eps=1e-6,
+                 name='gbt'):
+    """Add the gradient-boosted ensemble TREES with learning rate RATE and
+    initial value INIT to MDL and return the variable that holds its
+    prediction."""
+    outputs = [add_tree(mdl, x, tree, encoding, eps, '%s_%d' % (name, k))
+               for k, tree in enumerate(trees)]
+    y = mdl.continuous_var(lb=-mdl.infinity, name=name)
+    mdl.add_constraint(y == init + rate * mdl.sum(outputs), 'def_%s' % name)
+    return y
+
+
+def layers_from_onnx(filename):
+    """Read a feedforward network from the ONNX file FILENAME as a list of
+    (weights, biases). Only Gemm, MatMul, Add and Relu nodes are supported,
+    with a Relu after every layer except the last one."""
+    import onnx
+    from onnx import numpy_helper
+    graph = onnx.load(filename).graph
+    init = {t.name: numpy_helper.to_array(t) for t in graph.initializer}
+    layers = list()
+    relus = 0
+    for node in graph.node:
+        if node.op_type == 'Gemm':
+            W = init[node.input[1]]
+            trans = [a.i for a in node.attribute if a.name == 'transB']
+            if trans != [1]:
+                W = W.T
+            b = init[node.input[2]] if len(node.input) > 2 else [0.0] * len(W)
+            layers.append((W.tolist(), [float(v) for v in b]))
+        elif node.op_type == 'MatMul':
+            W = init[node.input[1]].T
+            layers.append((W.tolist(), [0.0] * len(W)))
+        elif node.op_type == 'Add':
+            b = init[node.input[1] if node.input[1] in init else node.input[0]]
+            layers[-1] = (layers[-1][0], [float(v) for v in b.flatten()])
+        elif node.op_type == 'Relu':
+            relus += 1
+        else:
+            raise Exception('Unsupported ONNX node %s' % node.op_type)
+    if relus != len(layers) - 1:
+        raise Exception('Expected a Relu after every layer except the last')
+    return layers
+
+
+def _interval(w, b, bounds):
+    lo = hi = b
+    for wi, (l, u) in zip(w, bounds):
+        lo += wi * (l if wi > 0 else u)
+        hi += wi * (u if wi > 0 else l)
+    return lo, hi
+
+
+def add_relu_network(mdl, x, layers, name='net'):
+    """Add the ReLU network LAYERS with input variables X to MDL and return
+    the list of output variables. The inputs need finite bounds."""
+    for xi in x:
+        if xi.lb <= -mdl.infinity or xi.ub >= mdl.infinity:
+            raise Exception('Input %s needs finite bounds' % xi.name)
+    h = list(x)
+    bounds = [(xi.lb, xi.ub) for xi in x]
+    for k, (W, b) in enumerate(layers):
+        last = k == len(layers) - 1
+        out, out_bounds = list(), list()
+        for j, (w, bj) in enumerate(zip(W, b)):
+            lo, hi = _interval(w, bj, bounds)
+            a = mdl.sum(wi * hk for wi, hk in zip(w, h) if wi != 0) + bj
+            vname = '%s_%d_%d' % (name, k, j)
+            if last:
+                v = mdl.continuous_var(lb=lo, ub=hi, name=vname)
+                mdl.add_constraint(v == a, 'def_%s' % vname)
+            elif hi <= 0:
+                # Always off: the neuron does not contribute.
+                v = 0
+                lo = hi = 0
+            elif lo >= 0:
+                v = mdl.continuous_var(lb=lo, ub=hi, name=vname)
+                mdl.add_constraint(v == a, 'def_%s' % vname)
+            else:
+                v = mdl.continuous_var(lb=0, ub=hi, name=vname)
+                z = mdl.binary_var(name='%s_on' % vname)
+                mdl.add_constraint(v >= a, '%s_ge' % vname)
+                mdl.add_constraint(v <= hi * z, '%s_off' % vname)
+                mdl.add_constraint(v <= a - lo * (1 - z), '%s_on' % vname)
+                lo = 0
+            out.append(v)
+            out_bounds.append((lo, hi))
+        h, bounds = out, out_bounds
+    return h
+
+
+if __name__ == "__main__":
+    # Two small trees that predict the yield of a process from temperature
+    # (feature 0) and pressure (feature 1), as a boosted ensemble would
+    # learn them.
+    trees = [
+        Tree(left=[1, 3, 5, -1, -1, -1, -1], right=[2, 4, 6, -1, -1, -1, -1],
+             feature=[0, 1, 1, -2, -2, -2, -2],
+             threshold=[150, 2.0, 3.0, 0, 0, 0, 0],
+             value=[0, 0, 0, 10, 20, 30, 25]),
+        Tree(left=[1, -1, 3, -1, -1], right=[2, -1, 4, -1, -1],
+             feature=[1, -2, 0, -2, -2],
+             threshold=[1.5, 0, 180, 0, 0],
+             value=[0, -4, 0, 6, 2]),
+    ]
+    with Model(name='ml_embedding') as m:
+        temperature = m.continuous_var(lb=100, ub=200, name='temperature')
+        pressure = m.continuous_var(lb=1, ub=4, name='pressure')
+        x = [temperature, pressure]
+        predicted = add_ensemble(m, x, trees, rate=1.0, init=40,
+                                 encoding='bigm')
+        # Energy cost as learned by a linear regression.
+        cost = add_linear_predictor(m, x, [0.1, 5.0], 2.0, name='cost')
+        m.maximize(predicted - cost)
+        sol = m.solve()
+        if sol is None:
+            print('No solution found')
+        else:
+            print('temperature %g, pressure %g: predicted yield %g, cost %g' %
+                  (temperature.solution_value, pressure.solution_value,
+                   predicted.solution_value, cost.solution_value))
+
+    # A network with two inputs, three hidden ReLU neurons and one output
+    # that approximates a quality score of the process.
+    net = [([[0.04, -1.0], [-0.02, 2.0], [0.01, 0.5]], [-4.0, 1.0, -3.0]),
+           ([[1.5, 0.8, -2.0]], [5.0])]
+    with Model(name='relu_embedding') as m:
+        temperature = m.continuous_var(lb=100, ub=200, name='temperature')
+        pressure = m.continuous_var(lb=1, ub=4, name='pressure')
+        score, = add_relu_network(m, [temperature, pressure], net)
+        m.maximize(score)
+        sol = m.solve()
+        if sol is None:
+            print('No solution found')
+        else:
+            print('temperature %g, pressure %g: score %g (%d binaries)' %
+                  (temperature.solution_value, pressure.solution_value,
+                   score.solution_value, m.number_of_binary_vars))
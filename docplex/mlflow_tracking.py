@@ -0,0 +1,103 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to record solves in an MLflow tracking server.
+#
+# Each solve becomes one MLflow run that contains
+# - the non-default CPLEX parameters and model statistics as parameters,
+# - KPIs (objective, best bound, gap, solve time, status) as metrics,
+# - the convergence curve: incumbent, bound and gap are logged as metrics
+#   with a step each time the progress listener is notified,
+# - the model as an LP file artifact, so that the run can be reproduced.
+# This makes solves comparable in the same UI that is used for machine
+# learning experiments.
+#
+# The tracking server is taken from the MLFLOW_TRACKING_URI environment
+# variable; without it runs are written to ./mlruns. Requires the mlflow
+# package.
+import os
+import random
+import tempfile
+
+import mlflow
+
+from docplex.mp.model import Model
+from docplex.mp.progress import ProgressClock, ProgressListener
+
+
+class MlflowProgressListener(ProgressListener):
+    """Logs the convergence of a MIP solve to the active MLflow run."""
+
+    def __init__(self):
+        ProgressListener.__init__(self, ProgressClock.Gap)
+        self.step = 0
+
+    def notify_progress(self, pdata):
+        metrics = {'progress_bound': pdata.best_bound}
+        if pdata.has_incumbent:
+            metrics['progress_objective'] = pdata.current_objective
+            metrics['progress_gap'] = pdata.mip_gap
+        mlflow.log_metrics(metrics, step=self.step)
+        mlflow.log_metric('progress_time', pdata.time, step=self.step)
+        self.step += 1
+
+
+def tracked_solve(mdl, experiment='cplex', run_name=None, **kwargs):
+    """Solve MDL inside an MLflow run. Returns the solution or None."""
+    mlflow.set_experiment(experiment)
+    with mlflow.start_run(run_name=run_name or mdl.name):
+        mlflow.log_params({'model': mdl.name,
+                           'variables': mdl.number_of_variables,
+                           'constraints': mdl.number_of_constraints,
+                           'integer_variables': mdl.number_of_integer_vars +
+                           mdl.number_of_binary_vars})
+        mlflow.log_params({p.qualified_name: p.get()
+                           for p in mdl.parameters.iter_params()
+                           if p.is_nondefault()})
+        with tempfile.TemporaryDirectory() as tmp:
+            mdl.export_as_lp(os.path.join(tmp, mdl.name + '.lp'))
+            mlflow.log_artifacts(tmp, artifact_path='model')
+
+        listener = MlflowProgressListener()
+        mdl.add_progress_listener(listener)
+        try:
+            sol = mdl.solve(**kwargs)
+        finally:
+            mdl.remove_progress_listener(listener)
+        details = mdl.solve_details
+        mlflow.set_tag('status', details.status)
+        metrics = {'solve_time': details.time}
+        if sol is not None:
+            metrics['objective'] = sol.get_objective_value()
+            if mdl.number_of_integer_vars + mdl.number_of_binary_vars > 0:
+                metrics['best_bound'] = details.best_bound
+                metrics['gap'] = details.mip_relative_gap
+                metrics['nodes'] = details.nb_nodes_processed
+        mlflow.log_metrics(metrics)
+        return sol
+
+
+if __name__ == "__main__":
+    rnd = random.Random(3)
+    Items = range(60)
+    weight = [rnd.randint(10, 60) for i in Items]
+    value = [w + rnd.randint(-5, 15) for w in weight]
+    Bins = range(4)
+
+    with Model(name='multiknapsack') as m:
+        x = m.binary_var_matrix(Items, Bins, name='x')
+        for i in Items:
+            m.add_constraint(m.sum(x[i, b] for b in Bins) <= 1)
+        for b in Bins:
+            m.add_constraint(m.sum(weight[i] * x[i, b] for i in Items) <= 300)
+        m.maximize(m.sum(value[i] * x[i, b] for i in Items for b in Bins))
+        m.parameters.timelimit = 20
+        for emphasis in (0, 1, 2):
+            m.parameters.emphasis.mip = emphasis
+            sol = tracked_solve(m, run_name='emphasis_%d' % emphasis)
+            if sol is not None:
+                print('Emphasis %d: objective %g' %
+                      (emphasis, sol.get_objective_value()))
@@ -0,0 +1,103 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to check models against a set of validation rules, for
+# example as part of a CI pipeline that runs against exported .lp/.mps files.
+#
+# Rules are functions that take a model and yield messages for everything
+# they find wrong. They are registered with the @rule decorator. The script
+# reads all models given on the command line, runs all rules and exits with
+# a non-zero status if any rule reported a violation. No solve is performed,
+# but ModelReader uses the CPLEX runtime to parse LP, MPS and SAV files, so
+# the CI machine needs a CPLEX installation. The rules themselves only use
+# docplex and can also be run on models built in memory (see dry_run.py).
+#
+# Usage: python model_rules.py <model file> [<model file> ...]
+import sys
+
+from docplex.mp.constr import LinearConstraint
+from docplex.mp.model_reader import ModelReader
+
+RULES = list()
+
+
+def rule(f):
+    """Decorator that registers F as validation rule."""
+    RULES.append(f)
+    return f
+
+
+def terms(ct):
+    """Iterate over (variable, coefficient) pairs of linear or range
+    constraint CT, with the variables of both sides moved to the left."""
+    if isinstance(ct, LinearConstraint):
+        return (ct.get_left_expr() - ct.get_right_expr()).iter_terms()
+    return ct.expr.iter_terms()
+
+
+@rule
+def coefficient_magnitude(mdl, max_coef=1e7, min_coef=1e-7):
+    """No coefficient must be above MAX_COEF or below MIN_COEF in absolute
+    value."""
+    for ct in mdl.iter_linear_constraints():
+        for v, coef in terms(ct):
+            if abs(coef) > max_coef or 0 < abs(coef) < min_coef:
+                yield 'constraint %s: coefficient %g for %s is outside [%g, %g]' % \
+                    (ct.name, coef, v.name, min_coef, max_coef)
+
+
+@rule
+def demand_has_slack(mdl, ct_prefix='demand', var_prefix='slack'):
+    """Every constraint whose name starts with CT_PREFIX must contain a
+    variable whose name starts with VAR_PREFIX."""
+    for ct in mdl.iter_linear_constraints():
+        if ct.name is None or not ct.name.startswith(ct_prefix):
+            continue
+        if not any(v.name is not None and v.name.startswith(var_prefix)
+                   for v in ct.iter_variables()):
+            yield 'constraint %s has no %s variable; add one so that ' \
+                  'infeasible demand can be detected' % (ct.name, var_prefix)
+
+
+@rule
+def huge_bounds(mdl, limit=1e10):
+    """Finite bounds must not be huge. Such bounds are usually meant to be
+    infinite and should be written as such."""
+    for v in mdl.iter_variables():
+        for which, b in (('lower', v.lb), ('upper', v.ub)):
+            if limit <= abs(b) < mdl.infinity:
+                yield 'variable %s: %s bound %g looks like a placeholder ' \
+                      'for infinity' % (v.name, which, b)
+
+
+@rule
+def empty_constraints(mdl):
+    """Constraints must not be empty."""
+    for ct in mdl.iter_linear_constraints():
+        if not any(True for _ in ct.iter_variables()):
+            yield 'constraint %s has no variables' % ct.name
+
+
+def validate(mdl, rules=RULES):
+    """Run RULES on MDL and return a list of (rule name, message) pairs."""
+    failures = list()
+    for r in rules:
+        for msg in r(mdl):
+            failures.append((r.__name__, msg))
+    return failures
+
+
+if __name__ == "__main__":
+    failed = False
+    for filename in sys.argv[1:]:
+        m = ModelReader.read(filename)
+        failures = validate(m)
+        print('%s: %d violations' % (filename, len(failures)))
+        for name, msg in failures:
+            print('  [%s] %s' % (name, msg))
+        failed = failed or len(failures) > 0
+        m.end()
+    sys.exit(1 if failed else 0)
@@ -0,0 +1,187 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to check that an MPS file written by another modeling
+# tool (Pyomo, JuMP, ...) is read by CPLEX with the intended meaning.
+#
+# MPS has several features that writers and readers handle differently:
+# - an RHS entry for the objective row is an objective constant, and some
+#   tools write it with the opposite sign,
+# - RANGES turn a row into a ranged row, with a meaning that depends on the
+#   sign of the range value for equality rows,
+# - integer columns are marked with MARKER lines (INTORG/INTEND); integer
+#   columns without explicit bounds get upper bound infinity in CPLEX, but
+#   upper bound 1 in some older readers,
+# - OBJSENSE may be given as a section or not at all.
+# The file is scanned directly (free MPS format: names must not contain
+# spaces) and the result is compared with what CPLEX read. Any difference
+# is reported, so problems show up before models are solved.
+#
+# The directory mps_samples contains small files with each of these
+# features. SAMPLES lists what scan() and check() must find in them.
+#
+# Usage: python mps_check.py <file.mps>
+#        python mps_check.py --samples
+import os
+import sys
+
+import cplex
+
+
+def scan(filename):
+    """Scan the MPS file and return a summary dictionary."""
+    info = {'objsense': 'MIN', 'objective': None, 'offset': 0.0,
+            'integers': set(), 'bounded': set(), 'ranges': set(),
+            'columns': set(), 'rows': 0}
+    section = None
+    integer = False
+    with open(filename) as f:
+        for line in f:
+            if line.strip() == '' or line.startswith('*'):
+                continue
+            if not line[0].isspace():
+                words = line.split()
+                section = words[0]
+                if section == 'OBJSENSE' and len(words) > 1:
+                    info['objsense'] = words[1]
+                continue
+            words = line.split()
+            if section == 'OBJSENSE':
+                info['objsense'] = words[0]
+            elif section == 'ROWS':
+                if words[0] == 'N':
+                    if info['objective'] is None:
+                        info['objective'] = words[1]
+                else:
+                    info['rows'] += 1
+            elif section == 'COLUMNS':
+                if len(words) > 2 and words[1] == "'MARKER'":
+                    integer = words[2] == "'INTORG'"
+                    continue
+                info['columns'].add(words[0])
+                if integer:
+                    info['integers'].add(words[0])
+            elif section == 'RHS':
+                pairs = words[1:] if len(words) % 2 == 1 else words
+                for row, value in zip(pairs[0::2], pairs[1::2]):
+                    if row == info['objective']:
+                        # CPLEX reads an objective RHS as minus the constant.
+                        info['offset'] = -float(value)
+            elif section == 'RANGES':
+                pairs = words[1:] if len(words) % 2 == 1 else words
+                info['ranges'].update(pairs[0::2])
+            elif section == 'BOUNDS':
+                # The bound set name is optional. FR, MI, PL and BV take no
+                # value, but some writers add one to BV.
+                kind = words[0]
+                size = 2 if kind in ('FR', 'MI', 'PL', 'BV') else 3
+                if kind == 'BV' and len(words) == 3:
+                    size = 3 if words[1] in info['columns'] else 2
+                col = words[1] if len(words) <= size else words[2]
+                if kind in ('BV', 'LI', 'UI'):
+                    info['integers'].add(col)
+                if kind in ('UP', 'UI', 'BV', 'FX', 'PL', 'FR'):
+                    info['bounded'].add(col)
+    return info
+
+
+def check(filename):
+    """Compare the scanned file with the model read by CPLEX.
+    Returns the list of problems found."""
+    info = scan(filename)
+    cpx = cplex.Cplex()
+    cpx.set_results_stream(None)
+    cpx.read(filename, 'mps')
+    problems = list()
+    try:
+        sense = 'MAX' if cpx.objective.get_sense() == cpx.objective.sense.maximize else 'MIN'
+        if not info['objsense'].startswith(sense):
+            problems.append('objective sense is %s in the file but %s in CPLEX' %
+                            (info['objsense'], sense))
+        offset = cpx.objective.get_offset()
+        if abs(offset - info['offset']) > 1e-9 * max(1.0, abs(offset)):
+            problems.append('objective constant %g in CPLEX, expected %g '
+                            '(sign convention of the writer?)' %
+                            (offset, info['offset']))
+        if cpx.linear_constraints.get_num() != info['rows']:
+            problems.append('%d rows in the file, %d in CPLEX' %
+                            (info['rows'], cpx.linear_constraints.get_num()))
+        ranged = set(name for name, s in
+                     zip(cpx.linear_constraints.get_names(),
+                         cpx.linear_constraints.get_senses()) if s == 'R')
+        if ranged != info['ranges']:
+            problems.append('ranged rows differ: %s' %
+                            sorted(ranged.symmetric_difference(info['ranges'])))
+        names = cpx.variables.get_names()
+        try:
+            types = cpx.variables.get_types() if len(names) > 0 else []
+        except cplex.exceptions.CplexError:
+            # Continuous problem: there are no types.
+            types = ['C'] * len(names)
+        uppers = cpx.variables.get_upper_bounds() if len(names) > 0 else []
+        for name, t, ub in zip(names, types, uppers):
+            if name in info['integers'] and t not in 'IB':
+                problems.append('%s is integer in the file but %s in CPLEX' %
+                                (name, t))
+            elif name not in info['integers'] and t in 'IB':
+                problems.append('%s is continuous in the file but %s in CPLEX' %
+                                (name, t))
+            if name in info['integers'] and name not in info['bounded'] and \
+               ub >= cplex.infinity:
+                problems.append('%s is integer without upper bound: CPLEX uses '
+                                'infinity, some readers use 1' % name)
+    finally:
+        cpx.end()
+    return problems
+
+
+# File name, expected scan() results, expected check() problems.
+SAMPLES = [
+    ('objective_constant.mps', {'objsense': 'MAX', 'offset': 5.0}, []),
+    ('ranges.mps', {'ranges': {'r1', 'r2', 'r3', 'r4'}}, []),
+    ('integer_markers.mps', {'integers': {'x', 'w', 'z'},
+                             'bounded': {'x', 'y', 'z'}},
+     ['w is integer without upper bound']),
+]
+
+
+def check_samples(directory):
+    """Run scan() and check() on the SAMPLES in DIRECTORY. Returns the list
+    of failures."""
+    failures = list()
+    for filename, expected, problems in SAMPLES:
+        path = os.path.join(directory, filename)
+        info = scan(path)
+        for key, value in sorted(expected.items()):
+            if info[key] != value:
+                failures.append('%s: %s is %s, expected %s' %
+                                (filename, key, info[key], value))
+        found = check(path)
+        missing = [p for p in problems
+                   if not any(f.startswith(p) for f in found)]
+        if len(missing) > 0 or len(found) != len(problems):
+            failures.append('%s: check() found %s, expected %s' %
+                            (filename, found, problems))
+    return failures
+
+
+if __name__ == "__main__":
+    if len(sys.argv) != 2:
+        print('Usage: python mps_check.py <file.mps>')
+        print('       python mps_check.py --samples')
+        sys.exit(2)
+    if sys.argv[1] == '--samples':
+        failures = check_samples(os.path.join(os.path.dirname(
+            os.path.abspath(__file__)), 'mps_samples'))
+        for f in failures:
+            print(f)
+        print('%d samples, %d failures' % (len(SAMPLES), len(failures)))
+        sys.exit(1 if len(failures) > 0 else 0)
+    problems = check(sys.argv[1])
+    for p in problems:
+        print(p)
+    print('%d problems found' % len(problems))
+    sys.exit(1 if len(problems) > 0 else 0)
@@ -0,0 +1,21 @@
+* Integer columns marked with INTORG/INTEND, and bounds written without
+* the optional bound set name. w is integer without bounds: CPLEX gives
+* it upper bound infinity, some readers use 1.
+NAME          integer_markers
+ROWS
+ N  cost
+ G  demand
+COLUMNS
+    MARKER    'MARKER'  'INTORG'
+    x         cost      4              demand    3
+    w         cost      3              demand    2
+    MARKER    'MARKER'  'INTEND'
+    y         cost      1              demand    1
+    z         cost      2              demand    1
+RHS
+    rhs       demand    7
+BOUNDS
+ UP x         4
+ FR y
+ BV z
+ENDATA
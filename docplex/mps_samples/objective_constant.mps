@@ -0,0 +1,17 @@
+* Maximization with an objective constant of 5. The constant is given as an
+* RHS entry of the objective row, with the sign convention of CPLEX:
+* RHS = -constant.
+NAME          objective_constant
+OBJSENSE
+    MAX
+ROWS
+ N  profit
+ L  cap
+COLUMNS
+    x         profit    3              cap       1
+    y         profit    2              cap       1
+RHS
+    rhs       cap       10             profit    -5
+BOUNDS
+ UP bnd       x         8
+ENDATA
@@ -0,0 +1,21 @@
+* Ranged rows of every sense. For E rows the sign of the range value
+* decides whether the range lies above or below the RHS.
+NAME          ranges
+ROWS
+ N  cost
+ L  r1
+ G  r2
+ E  r3
+ E  r4
+COLUMNS
+    x         cost      1              r1        1
+    x         r2        1              r3        1
+    y         cost      1              r4        1
+    y         r1        1
+RHS
+    rhs       r1        10             r2        2
+    rhs       r3        5              r4        5
+RANGES
+    rng       r1        4              r2        4
+    rng       r3        3              r4        -3
+ENDATA
@@ -0,0 +1,108 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to control the names of variables and constraints
+# when exporting a model.
+#
+# Generated names such as "flow_warehouse_Springfield_customer_1234_day_17"
+# are readable but make files large, and the LP format limits names to 255
+# characters. A naming policy is a function that maps an original name and
+# the index of the entity to a new name:
+# - indexed: a template such as "x%d" or "c%d", the shortest possible names,
+# - shortened: names longer than a limit are cut and get a hash suffix so
+#   that they stay unique, shorter names are kept,
+# - keep: the original names.
+# The mapping from new to original names is written to a CSV file so that
+# solutions and conflicts reported with the new names can be translated
+# back.
+#
+# The names are changed with the CPLEX Python API after reading the model,
+# so this works for any model file.
+#
+# Usage: python naming_policies.py <model file> <output file> [indexed|shortened|keep]
+import csv
+import hashlib
+import sys
+
+import cplex
+
+
+def indexed(template):
+    def policy(name, index):
+        return template % index
+    return policy
+
+
+def shortened(limit=255, digits=8):
+    def policy(name, index):
+        if len(name) <= limit:
+            return name
+        h = hashlib.sha1(name.encode('utf-8')).hexdigest()[:digits]
+        return name[:limit - digits - 1] + '#' + h
+    return policy
+
+
+def keep(name, index):
+    return name
+
+
+POLICIES = {
+    'indexed': (indexed('x%d'), indexed('c%d')),
+    'shortened': (shortened(), shortened()),
+    'keep': (keep, keep),
+}
+
+
+def rename(cpx, var_policy, row_policy):
+    """Rename the variables and linear constraints of CPX. Returns a list of
+    (kind, new name, original name) for all renamed entities."""
+    mapping = list()
+    for kind, entities, policy in (('var', cpx.variables, var_policy),
+                                   ('row', cpx.linear_constraints, row_policy)):
+        names = entities.get_names() if entities.get_num() > 0 else []
+        changes = list()
+        used = set()
+        for index, name in enumerate(names):
+            new = policy(name, index)
+            if new in used:
+                raise Exception('Naming policy produced duplicate name ' + new)
+            used.add(new)
+            if new != name:
+                changes.append((index, new))
+                mapping.append((kind, new, name))
+        if len(changes) > 0:
+            entities.set_names(changes)
+    return mapping
+
+
+def write_mapping(filename, mapping):
+    with open(filename, 'w') as f:
+        w = csv.writer(f)
+        w.writerow(['kind', 'name', 'original'])
+        w.writerows(mapping)
+
+
+def read_mapping(filename):
+    """Read a mapping written by write_mapping(). Returns a dictionary that
+    maps (kind, new name) to the original name."""
+    with open(filename) as f:
+        return {(row['kind'], row['name']): row['original']
+                for row in csv.DictReader(f)}
+
+
+if __name__ == "__main__":
+    if len(sys.argv) < 3:
+        print('Usage: python naming_policies.py <model file> <output file> '
+              '[indexed|shortened|keep]')
+        sys.exit(2)
+    policy = sys.argv[3] if len(sys.argv) > 3 else 'indexed'
+    cpx = cplex.Cplex(sys.argv[1])
+    mapping = rename(cpx, *POLICIES[policy])
+    cpx.write(sys.argv[2])
+    if len(mapping) > 0:
+        write_mapping(sys.argv[2] + '.names.csv', mapping)
+    print('Renamed %d entities' % len(mapping))
+    cpx.end()
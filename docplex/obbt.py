@@ -0,0 +1,145 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to implement optimization-based bound tightening (OBBT)
+# for a DOcplex model.
+#
+# For each selected variable x we minimize and maximize x subject to the LP
+# relaxation of the model. The optimal values are valid bounds for x in any
+# feasible solution of the model, and they are often much tighter than the
+# bounds that were specified when the model was built. Tight bounds are
+# important for big-M formulations, piecewise linear functions and
+# nonconvex models.
+#
+# The LPs for different variables are independent, so they can be solved in
+# parallel. Each worker then uses its own copy of the relaxation.
+import math
+from concurrent.futures import ThreadPoolExecutor
+
+from docplex.mp.model import Model
+from docplex.mp.relax_linear import LinearRelaxer
+
+
+def tighten(relaxed, indices, tol):
+    """Compute new bounds for the variables with indices INDICES by
+    minimizing and maximizing them over the model RELAXED.
+    Returns a list of (index, lb, ub) triplets. A bound is None if the
+    respective LP did not have an optimal solution."""
+    result = list()
+    for j in indices:
+        x = relaxed.get_var_by_index(j)
+        bounds = list()
+        for sense in ('min', 'max'):
+            relaxed.set_objective(sense, x)
+            sol = relaxed.solve()
+            if sol is None:
+                if relaxed.solve_details.status_code == 3:
+                    # 3 = CPX_STAT_INFEASIBLE
+                    raise Exception('LP relaxation is infeasible')
+                bounds.append(None)
+            else:
+                bounds.append(sol.get_objective_value())
+        lb, ub = bounds
+        # Update the relaxation so that subsequent LPs benefit from the
+        # tightened bounds.
+        if lb is not None and lb > x.lb + tol:
+            x.lb = lb
+        if ub is not None and ub < x.ub - tol:
+            x.ub = ub
+        result.append((j, lb, ub))
+    return result
+
+
+def obbt(mdl, variables=None, rounds=1, tol=1e-6, workers=1):
+    """Tighten the bounds of VARIABLES (default: all variables) in MDL.
+
+    The bounds in MDL are updated in place. ROUNDS gives the number of
+    passes over the variables, WORKERS gives the number of LPs that are
+    solved in parallel.
+    Returns the number of bounds that were changed.
+    """
+    if variables is None:
+        variables = list(mdl.iter_variables())
+    indices = [v.index for v in variables]
+    changed = 0
+    for r in range(rounds):
+        # Split variables into one chunk per worker. Each chunk is processed
+        # with a private copy of the LP relaxation since models must not be
+        # shared between threads.
+        chunks = [indices[k::workers] for k in range(workers)]
+        chunks = [c for c in chunks if len(c) > 0]
+        relaxations = list()
+        for c in chunks:
+            relaxed = LinearRelaxer.make_relaxed_model(mdl)
+            relaxed.parameters.threads = 1
+            relaxations.append(relaxed)
+        with ThreadPoolExecutor(max_workers=len(chunks)) as executor:
+            futures = [executor.submit(tighten, relaxations[k], chunks[k], tol)
+                       for k in range(len(chunks))]
+            results = [f.result() for f in futures]
+        for relaxed in relaxations:
+            relaxed.end()
+        # Transfer the new bounds back to the original model.
+        changed_this_round = 0
+        for res in results:
+            for j, lb, ub in res:
+                x = mdl.get_var_by_index(j)
+                if x.is_integer() or x.is_binary():
+                    lb = None if lb is None else math.ceil(lb - tol)
+                    ub = None if ub is None else math.floor(ub + tol)
+                if lb is not None and lb > x.lb + tol:
+                    x.lb = lb
+                    changed_this_round += 1
+                if ub is not None and ub < x.ub - tol:
+                    x.ub = ub
+                    changed_this_round += 1
+        print('Round %d: %d bounds tightened' % (r, changed_this_round))
+        changed += changed_this_round
+        if changed_this_round == 0:
+            break
+    return changed
+
+
+def build_model(nbSites=5, demand=100):
+    """A simple facility location model with big-M constraints. The M used
+    here is deliberately loose."""
+    m = Model(name='facilities')
+    Sites = range(nbSites)
+    fixed = [100 + 10 * i for i in Sites]
+    unit = [10 - i for i in Sites]
+    cap = [30 + 5 * i for i in Sites]
+    bigM = 1e6
+    x = m.continuous_var_list(Sites, ub=bigM, name='x')
+    y = m.binary_var_list(Sites, name='y')
+    m.add_constraint(m.sum(x) == demand, 'demand')
+    for i in Sites:
+        m.add_constraint(x[i] <= bigM * y[i], 'link_%d' % i)
+        m.add_constraint(x[i] + 2 * x[(i + 1) % nbSites] <= 2 * cap[i],
+                         'capacity_%d' % i)
+    m.minimize(m.sum(fixed[i] * y[i] + unit[i] * x[i] for i in Sites))
+    return m, x, y
+
+
+if __name__ == "__main__":
+    m, x, y = build_model()
+    print('Bounds before OBBT:')
+    for v in x:
+        print('  %s in [%g, %g]' % (v.name, v.lb, v.ub))
+    obbt(m, x, rounds=3, workers=2)
+    print('Bounds after OBBT:')
+    for v in x:
+        print('  %s in [%g, %g]' % (v.name, v.lb, v.ub))
+
+    # With the tightened bounds we can replace the big-M in the linking
+    # constraints by the upper bound of each variable.
+    for i, v in enumerate(x):
+        m.remove_constraint('link_%d' % i)
+        m.add_constraint(v <= v.ub * y[i], 'link_%d' % i)
+
+    sol = m.solve(log_output=True)
+    assert sol is not None
+    m.report()
+    m.end()
@@ -0,0 +1,99 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to sample feasible solutions of a MIP with the solution
+# pool and analyze them, to understand why the gap does not close.
+#
+# The populate procedure of CPLEX is run with replacement strategy set to
+# 'diversity' so that the pool holds solutions that are spread out. The
+# report shows
+# - the distribution of objective values in the pool,
+# - the "backbone" of the good solutions, that is the discrete variables
+#   that have the same value in all solutions within a given relative
+#   distance from the best one, and the variables that vary among them.
+# A large backbone that contains many variables which are fractional in the
+# LP relaxation often hints at a weak formulation. Many good solutions that
+# differ a lot hint at symmetry.
+#
+# Usage: python objective_landscape.py <model file> [pool size]
+import sys
+
+from docplex.mp.model_reader import ModelReader
+
+
+def sample(mdl, size, timelimit=60):
+    """Populate the solution pool of MDL with up to SIZE solutions."""
+    mdl.parameters.mip.pool.capacity = size
+    mdl.parameters.mip.limits.populate = size
+    mdl.parameters.mip.pool.intensity = 4
+    # Replace solutions so as to keep the pool diverse.
+    mdl.parameters.mip.pool.replace = 2
+    mdl.parameters.timelimit = timelimit
+    pool = mdl.populate_solution_pool()
+    return [] if pool is None else list(pool)
+
+
+def histogram(values, buckets=10):
+    lo = min(values)
+    hi = max(values)
+    width = (hi - lo) / buckets if hi > lo else 1.0
+    counts = [0] * buckets
+    for v in values:
+        counts[min(int((v - lo) / width), buckets - 1)] += 1
+    for k, c in enumerate(counts):
+        print('  [%12g, %12g) %5d %s' %
+              (lo + k * width, lo + (k + 1) * width, c, '#' * c))
+
+
+def backbone(mdl, solutions, tol=1e-6):
+    """Return the discrete variables that have the same value in all
+    SOLUTIONS, and the ones that do not."""
+    fixed = list()
+    varying = list()
+    for v in mdl.iter_variables():
+        if not v.is_discrete():
+            continue
+        values = [s.get_value(v) for s in solutions]
+        if max(values) - min(values) <= tol:
+            fixed.append(v)
+        else:
+            varying.append(v)
+    return fixed, varying
+
+
+def report(mdl, solutions, relgap=0.01):
+    if len(solutions) == 0:
+        print('No solutions')
+        return
+    objs = sorted(s.get_objective_value() for s in solutions)
+    if mdl.is_maximized():
+        objs.reverse()
+    best = objs[0]
+    print('%d solutions, best %g, worst %g, median %g' %
+          (len(objs), best, objs[-1], objs[len(objs) // 2]))
+    histogram(objs)
+
+    good = [s for s in solutions
+            if abs(s.get_objective_value() - best) <= relgap * max(1.0, abs(best))]
+    fixed, varying = backbone(mdl, good)
+    print('%d solutions within %g%% of best' % (len(good), 100.0 * relgap))
+    print('  %d discrete variables agree in all of them' % len(fixed))
+    print('  %d discrete variables vary:' % len(varying))
+    for v in varying[:20]:
+        values = sorted(set(round(s.get_value(v)) for s in good))
+        print('    %s: %s' % (v.name, values))
+    if len(varying) > 20:
+        print('    ...')
+
+
+if __name__ == "__main__":
+    size = 50
+    if len(sys.argv) > 2:
+        size = int(sys.argv[2])
+    m = ModelReader.read(sys.argv[1])
+    solutions = sample(m, size)
+    report(m, solutions)
+    m.end()
@@ -0,0 +1,89 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to re-optimize efficiently when only the objective
+# coefficients change, as in a pricing loop.
+#
+# If only the objective changes, every previous solution is still feasible:
+# - for an LP, the previous optimal basis stays primal feasible, and CPLEX
+#   starts from it automatically (advanced start), so the primal simplex
+#   usually needs few iterations. This is why the LP method is set to
+#   primal here.
+# - for a MIP, the search tree cannot be reused, but all solutions found so
+#   far can be given back as MIP starts. They only need to be checked for
+#   feasibility (effort level CheckFeas), and the best of them under the new
+#   objective is immediately available as incumbent.
+#
+# update_objective() applies a dictionary of coefficient changes to the
+# objective. Reoptimizer keeps the solutions of previous solves and adds
+# them as MIP starts before each new solve.
+import random
+
+from docplex.mp.constants import EffortLevel
+from docplex.mp.model import Model
+
+
+def update_objective(mdl, delta):
+    """Add DELTA[v] to the objective coefficient of each variable v."""
+    expr = mdl.objective_expr.copy()
+    for v, d in delta.items():
+        expr.add_term(v, d)
+    mdl.set_objective(mdl.objective_sense, expr)
+
+
+class Reoptimizer(object):
+    """Solves a model repeatedly, reusing previous solutions as MIP starts."""
+
+    def __init__(self, mdl, keep=10):
+        self.mdl = mdl
+        self.keep = keep
+        self.solutions = list()
+        if mdl.number_of_integer_vars + mdl.number_of_binary_vars == 0:
+            # Primal simplex: the previous basis stays primal feasible.
+            mdl.parameters.lpmethod = 1
+
+    def solve(self, delta=None, **kwargs):
+        mdl = self.mdl
+        if delta:
+            update_objective(mdl, delta)
+        if mdl.number_of_integer_vars + mdl.number_of_binary_vars > 0:
+            mdl.clear_mip_starts()
+            for sol in self.solutions:
+                mdl.add_mip_start(sol, effort_level=EffortLevel.CheckFeas)
+        sol = mdl.solve(**kwargs)
+        if sol is not None:
+            self.solutions.append(sol)
+            self.solutions = self.solutions[-self.keep:]
+        return sol
+
+
+if __name__ == "__main__":
+    rnd = random.Random(5)
+    Plants = range(8)
+    Markets = range(30)
+    capacity = [rnd.randint(60, 120) for p in Plants]
+    demand = [rnd.randint(10, 25) for k in Markets]
+
+    with Model(name='transport') as m:
+        x = m.continuous_var_matrix(Plants, Markets, name='x')
+        for p in Plants:
+            m.add_constraint(m.sum(x[p, k] for k in Markets) <= capacity[p])
+        for k in Markets:
+            m.add_constraint(m.sum(x[p, k] for p in Plants) == demand[k])
+        m.minimize(m.sum(rnd.randint(1, 20) * x[p, k]
+                         for p in Plants for k in Markets))
+        reopt = Reoptimizer(m)
+        sol = reopt.solve()
+        print('Initial: cost %g, %d iterations' %
+              (sol.get_objective_value(), m.solve_details.nb_iterations))
+        # Pricing loop: each round some transport prices change.
+        for it in range(5):
+            delta = {x[p, k]: rnd.uniform(-3, 3)
+                     for p in Plants for k in Markets if rnd.random() < 0.1}
+            sol = reopt.solve(delta)
+            print('Round %d: %d prices changed, cost %g, %d iterations' %
+                  (it, len(delta), sol.get_objective_value(),
+                   m.solve_details.nb_iterations))
@@ -0,0 +1,120 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to derive parameter suggestions from the statistics
+# of a single MIP solve.
+#
+# The CPLEX log of the solve is captured and parsed for the time spent at
+# the root, the number of cuts of each type, the node count, whether node
+# files were used and whether solution polishing ran. Simple rules then
+# turn these numbers into suggestions, each with the parameter concerned,
+# the suggested setting and the observation it is based on. For example:
+# - the root LP took most of the time: try the barrier algorithm,
+# - a time limit was hit with a remaining gap and polishing never ran: try
+#   polishing for the last part of the time,
+# - the search processed many nodes with a large remaining gap: try bound
+#   emphasis and more aggressive cuts,
+# - node files were written: give CPLEX more working memory.
+# This is no replacement for the tuning tool, which tries settings on
+# several instances, but it is a quick first check after a slow solve.
+#
+# Usage: python parameter_hints.py <model file> <time limit>
+import io
+import re
+import sys
+from collections import namedtuple
+
+from docplex.mp.model_reader import ModelReader
+
+Suggestion = namedtuple('Suggestion', ['parameter', 'setting', 'reason'])
+
+
+def parse_log(text):
+    """Extract statistics from a CPLEX MIP log."""
+    stats = {'root_lp_time': None, 'root_time': None, 'total_time': None,
+             'cuts': dict(), 'node_files': False, 'polishing': False,
+             'numerical': False}
+    m = re.search(r'Root relaxation solution time = ([0-9.]+) sec', text)
+    if m:
+        stats['root_lp_time'] = float(m.group(1))
+    m = re.search(r'Root node processing \(before b&c\):\s*Real time\s*=\s*'
+                  r'([0-9.]+) sec', text)
+    if m:
+        stats['root_time'] = float(m.group(1))
+    m = re.search(r'Total \(root\+branch&cut\)\s*=\s*([0-9.]+) sec', text)
+    if m:
+        stats['total_time'] = float(m.group(1))
+    for kind, count in re.findall(r'^(.+?) cuts applied:\s*(\d+)', text,
+                                  re.MULTILINE):
+        stats['cuts'][kind.strip()] = int(count)
+    stats['node_files'] = re.search(r'[Nn]ode file', text) is not None
+    stats['polishing'] = 'polishing' in text
+    stats['numerical'] = re.search(r'[Nn]umerical (difficulties|issues)',
+                                   text) is not None
+    return stats
+
+
+def suggest(stats, mdl, time_limit):
+    """Return a list of Suggestions from the log STATS and the solve details
+    of MDL."""
+    details = mdl.solve_details
+    suggestions = list()
+    total = stats['total_time'] or details.time
+    gap = details.mip_relative_gap if details.has_hit_limit() else 0.0
+    if stats['root_lp_time'] is not None and total > 0 and \
+       stats['root_lp_time'] > 0.5 * total:
+        suggestions.append(Suggestion(
+            'lpmethod', '4 (barrier)',
+            'the root LP took %.0f%% of the time' %
+            (100.0 * stats['root_lp_time'] / total)))
+    elif stats['root_time'] is not None and total > 0 and \
+            stats['root_time'] > 0.7 * total and details.nb_nodes_processed < 100:
+        suggestions.append(Suggestion(
+            'mip.cuts.*', '-1 for the families that add many cuts',
+            'root processing (cuts and heuristics) took %.0f%% of the time' %
+            (100.0 * stats['root_time'] / total)))
+    if details.has_hit_limit() and gap > 0.01:
+        if not stats['polishing']:
+            suggestions.append(Suggestion(
+                'mip.polishafter.time', '%g' % (0.8 * time_limit),
+                'the time limit was hit with gap %.2f%% and polishing never '
+                'ran' % (100.0 * gap)))
+        if details.nb_nodes_processed > 10000:
+            suggestions.append(Suggestion(
+                'emphasis.mip', '3 (bound)',
+                '%d nodes processed and the gap is still %.2f%%' %
+                (details.nb_nodes_processed, 100.0 * gap)))
+            if sum(stats['cuts'].values()) < 10:
+                suggestions.append(Suggestion(
+                    'mip.cuts.*', '2 (aggressive)',
+                    'few cuts were added but the bound is weak'))
+    if stats['node_files']:
+        suggestions.append(Suggestion(
+            'workmem', 'larger than the current %d MB' %
+            mdl.parameters.workmem.get(),
+            'node files were written to disk'))
+    if stats['numerical']:
+        suggestions.append(Suggestion(
+            'emphasis.numerical', '1', 'the log reports numerical difficulties'))
+    if sum(stats['cuts'].values()) >= 10:
+        kind, count = max(stats['cuts'].items(), key=lambda kc: kc[1])
+        suggestions.append(Suggestion(
+            'mip.cuts.*', 'keep default',
+            '%s cuts dominate (%d applied): the default cut strategy works '
+            'for this model' % (kind, count)))
+    return suggestions
+
+
+if __name__ == "__main__":
+    m = ModelReader.read(sys.argv[1])
+    time_limit = float(sys.argv[2])
+    m.parameters.timelimit = time_limit
+    log = io.StringIO()
+    m.solve(log_output=log)
+    stats = parse_log(log.getvalue())
+    for s in suggest(stats, m, time_limit):
+        print('%-22s %-40s %s' % s)
+    m.end()
@@ -0,0 +1,135 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to support interactive planning on top of a DOcplex
+# model: the planner pins some decisions ("keep these shipments on truck 2"),
+# re-solves, and can undo pinnings step by step.
+#
+# Pinnings are implemented as named equality constraints rather than as
+# variable bounds. This way they show up by name in the result of the
+# conflict refiner when the pinned decisions are mutually infeasible, and
+# removing them never interferes with bounds that are part of the model.
+from docplex.mp.conflict_refiner import ConflictRefiner
+from docplex.mp.model import Model
+
+
+class Pinning(object):
+    """Manages fixings of variables in a model with an undo stack."""
+
+    def __init__(self, mdl):
+        self.mdl = mdl
+        # Maps variable to (value, constraint) for all current fixings.
+        self.pins = dict()
+        # Each entry is a list of (variable, previous value or None).
+        self.undo_stack = list()
+
+    def _pin(self, v, value):
+        self._unpin(v)
+        ct = self.mdl.add_constraint(v == value, 'pin_%s' % v.name)
+        self.pins[v] = (value, ct)
+
+    def _unpin(self, v):
+        if v in self.pins:
+            self.mdl.remove_constraint(self.pins[v][1])
+            del self.pins[v]
+
+    def _previous(self, v):
+        return self.pins[v][0] if v in self.pins else None
+
+    def fix(self, variables, values):
+        """Fix each variable in VARIABLES to the respective value in VALUES."""
+        frame = [(v, self._previous(v)) for v in variables]
+        for v, value in zip(variables, values):
+            self._pin(v, value)
+        self.undo_stack.append(frame)
+
+    def unfix(self, variables):
+        """Remove the fixings for VARIABLES."""
+        frame = [(v, self._previous(v)) for v in variables]
+        for v in variables:
+            self._unpin(v)
+        self.undo_stack.append(frame)
+
+    def undo(self):
+        """Undo the last fix() or unfix() operation. Returns False if there
+        is nothing to undo."""
+        if len(self.undo_stack) == 0:
+            return False
+        frame = self.undo_stack.pop()
+        for v, value in reversed(frame):
+            if value is None:
+                self._unpin(v)
+            else:
+                self._pin(v, value)
+        return True
+
+    def solve(self, **kwargs):
+        """Solve the model with the current fixings.
+        If the model is infeasible, print the fixings that are part of a
+        conflict and return None."""
+        sol = self.mdl.solve(**kwargs)
+        if sol is None:
+            self.explain()
+        return sol
+
+    def explain(self):
+        """Run the conflict refiner and report the conflicting fixings."""
+        pinned = {ct.name: v for v, (value, ct) in self.pins.items()}
+        conflict = ConflictRefiner().refine_conflict(self.mdl)
+        fixings = list()
+        others = list()
+        for c in conflict.iter_conflicts():
+            if c.name in pinned:
+                fixings.append(pinned[c.name])
+            else:
+                others.append(c.name)
+        print('Infeasible. Conflicting fixings:')
+        for v in fixings:
+            print('  %s = %g' % (v.name, self.pins[v][0]))
+        if len(others) > 0:
+            print('  together with: %s' % ', '.join(str(n) for n in others))
+        return fixings
+
+
+if __name__ == "__main__":
+    Shipments = range(10)
+    Trucks = range(3)
+    weight = [4, 6, 3, 7, 5, 2, 8, 3, 4, 6]
+    capacity = [20, 18, 16]
+    cost = [[1 + (s * (t + 2)) % 7 for t in Trucks] for s in Shipments]
+
+    with Model(name='shipments') as m:
+        x = m.binary_var_matrix(Shipments, Trucks, name='x')
+        for s in Shipments:
+            m.add_constraint(m.sum(x[s, t] for t in Trucks) == 1, 'ship_%d' % s)
+        for t in Trucks:
+            m.add_constraint(m.sum(weight[s] * x[s, t] for s in Shipments)
+                             <= capacity[t], 'capacity_%d' % t)
+        m.minimize(m.sum(cost[s][t] * x[s, t] for s in Shipments for t in Trucks))
+
+        p = Pinning(m)
+        sol = p.solve()
+        print('Initial plan: %f' % sol.get_objective_value())
+
+        # Keep shipments 0, 1 and 2 on truck 2.
+        p.fix([x[0, 2], x[1, 2], x[2, 2]], [1, 1, 1])
+        sol = p.solve()
+        print('Plan with pinned shipments: %f' % sol.get_objective_value())
+
+        # Also put shipments 3 and 6 on truck 2. This exceeds its capacity.
+        p.fix([x[3, 2], x[6, 2]], [1, 1])
+        sol = p.solve()
+        assert sol is None
+
+        # Undo the last pinning and re-solve.
+        p.undo()
+        sol = p.solve()
+        print('Plan after undo: %f' % sol.get_objective_value())
+
+        # Release shipment 1 again.
+        p.unfix([x[1, 2]])
+        sol = p.solve()
+        print('Plan after unfix: %f' % sol.get_objective_value())
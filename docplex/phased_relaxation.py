@@ -0,0 +1,150 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to relax an infeasible model by constraint priority.
+#
+# Planners think about rules in terms of importance: "never exceed the
+# legal working time" is more important than "every order should be
+# shipped on time". Here each constraint that may be relaxed gets a
+# priority level (1 = least important). If the model is infeasible, the
+# constraints of level 1 are made relaxable first. If that is still
+# infeasible, level 2 is made relaxable as well, and so on. Once the model
+# is feasible, the violations are minimized lexicographically: first the
+# total violation of the most important relaxed level, then, with that
+# fixed, the next level and so on. So a more important rule is only broken
+# if breaking less important ones is not enough, even if breaking it would
+# cost fewer units of violation. The result reports the highest level that
+# had to be relaxed and which constraints were violated by how much.
+# Finally the original objective is optimized subject to the minimal
+# violations.
+#
+# Relaxation is implemented with explicit slack variables whose upper
+# bounds are 0 as long as their level is not relaxable.
+from docplex.mp.model import Model
+
+
+class PrioritizedModel(object):
+    """Wraps a model and tracks constraints with priorities."""
+
+    def __init__(self, mdl):
+        self.mdl = mdl
+        # List of (level, name, slack variables).
+        self.soft = list()
+
+    def add(self, lhs, sense, rhs, level, name):
+        """Add the constraint LHS SENSE RHS with priority LEVEL.
+        SENSE is one of '<=', '>=' or '=='."""
+        mdl = self.mdl
+        slacks = list()
+        if sense in ('<=', '=='):
+            s = mdl.continuous_var(ub=0, name='%s_over' % name)
+            slacks.append(s)
+            lhs = lhs - s
+        if sense in ('>=', '=='):
+            s = mdl.continuous_var(ub=0, name='%s_under' % name)
+            slacks.append(s)
+            lhs = lhs + s
+        if sense == '<=':
+            mdl.add_constraint(lhs <= rhs, name)
+        elif sense == '>=':
+            mdl.add_constraint(lhs >= rhs, name)
+        else:
+            mdl.add_constraint(lhs == rhs, name)
+        self.soft.append((level, name, slacks))
+
+    def solve(self, **kwargs):
+        """Solve the model, relaxing constraints by increasing level if it is
+        infeasible. Returns (solution, level, violations) where level is
+        the highest relaxed level (0 if nothing was relaxed) and violations
+        is a list of (level, name, amount)."""
+        mdl = self.mdl
+        objective = mdl.objective_expr
+        sense = mdl.objective_sense
+        sol = mdl.solve(**kwargs)
+        if sol is not None:
+            return sol, 0, []
+        levels = sorted(set(level for level, name, slacks in self.soft))
+        all_slacks = [s for level, name, slacks in self.soft for s in slacks]
+        fixed = list()
+        try:
+            for lvl in levels:
+                for level, name, slacks in self.soft:
+                    if level <= lvl:
+                        for s in slacks:
+                            s.ub = mdl.infinity
+                # Phase 1: minimize the violation level by level, starting
+                # with the most important relaxed level, and fix each
+                # minimum before going to the next level.
+                feasible = True
+                for current in reversed([l for l in levels if l <= lvl]):
+                    group = mdl.sum(s for level, name, slacks in self.soft
+                                    if level == current for s in slacks)
+                    mdl.minimize(group)
+                    sol = mdl.solve(**kwargs)
+                    if sol is None:
+                        feasible = False
+                        break
+                    violation = sol.get_objective_value()
+                    fixed.append(mdl.add_constraint(
+                        group <= violation * (1 + 1e-6) + 1e-9))
+                if not feasible:
+                    print('Relaxing level %d is not enough' % lvl)
+                    mdl.remove_constraints(fixed)
+                    fixed = list()
+                    continue
+                # Phase 2: optimize the original objective with minimal
+                # violations.
+                mdl.set_objective(sense, objective)
+                sol = mdl.solve(**kwargs)
+                if sol is None:
+                    return None, lvl, []
+                violations = [(level, name, sum(sol.get_value(s) for s in slacks))
+                              for level, name, slacks in self.soft]
+                return sol, lvl, [v for v in violations if v[2] > 1e-6]
+            return None, None, []
+        finally:
+            mdl.remove_constraints(fixed)
+            for s in all_slacks:
+                s.ub = 0
+            mdl.set_objective(sense, objective)
+
+
+if __name__ == "__main__":
+    Orders = range(6)
+    hours = [8, 6, 10, 4, 7, 5]
+    due = [1, 1, 1, 2, 2, 2]
+    Days = range(3)
+    capacity = 14
+
+    with Model(name='orders') as m:
+        x = m.binary_var_matrix(Orders, Days, name='x')
+        pm = PrioritizedModel(m)
+        for o in Orders:
+            m.add_constraint(m.sum(x[o, d] for d in Days) == 1)
+        for d in Days:
+            # Level 3: working time limit. Only broken as last resort.
+            pm.add(m.sum(hours[o] * x[o, d] for o in Orders), '<=', capacity,
+                   3, 'worktime_%d' % d)
+        for o in Orders:
+            # Level 1: orders must be done by their due day.
+            pm.add(m.sum(d * x[o, d] for d in Days), '<=', due[o] - 1,
+                   1, 'due_%d' % o)
+        # Level 2: orders 3 and 5 must be done on the same day.
+        # Relaxing the due dates is not enough, so either this rule or the
+        # working time limit must be broken. Two hours of overtime would be
+        # the smallest total violation, but the working time limit is more
+        # important, so the orders are split instead.
+        for d in Days:
+            pm.add(x[3, d] - x[5, d], '==', 0, 2, 'together_%d' % d)
+        m.minimize(m.sum(d * x[o, d] for o in Orders for d in Days))
+
+        sol, level, violations = pm.solve()
+        if sol is None:
+            print('Infeasible even with all levels relaxed')
+        else:
+            print('Highest level that had to be broken: %d' % level)
+            for lvl, name, amount in violations:
+                print('  level %d: %s violated by %g' % (lvl, name, amount))
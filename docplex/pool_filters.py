@@ -0,0 +1,101 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to use solution pool filters with a DOcplex model.
+#
+# Filters restrict the solutions that the populate procedure accepts into
+# the pool:
+# - a diversity filter bounds the (weighted) number of binary variables in
+#   which a solution differs from a reference solution,
+# - a range filter bounds a linear expression of the variables.
+# Filters can be defined programmatically, as done here, and written to or
+# read from .flt files.
+#
+# Usage: python pool_filters.py [filter file]
+#        If a filter file is given, filters are read from it instead of
+#        being created by the code.
+import os
+import random
+import sys
+import tempfile
+
+from docplex.mp.model import Model
+
+
+def add_diversity_filter(mdl, reference, lb, ub, weights=None, name=''):
+    """Add a diversity filter to MDL. REFERENCE is a dictionary that maps
+    binary variables to their reference values."""
+    cpx = mdl.get_cplex()
+    variables = list(reference)
+    ind = [v.index for v in variables]
+    val = [float(round(reference[v])) for v in variables]
+    if weights is not None:
+        weights = [float(weights[v]) for v in variables]
+    cpx.solution.pool.filter.add_diversity_filter(lb, ub, [ind, val],
+                                                  weights, name)
+
+
+def add_range_filter(mdl, expr, lb, ub, name=''):
+    """Add a range filter lb <= EXPR <= ub to MDL. EXPR must be a linear
+    expression of the model's variables."""
+    cpx = mdl.get_cplex()
+    ind = list()
+    val = list()
+    for v, coef in expr.iter_terms():
+        ind.append(v.index)
+        val.append(float(coef))
+    cpx.solution.pool.filter.add_range_filter(lb, ub, [ind, val], name)
+
+
+def print_filters(mdl):
+    flt = mdl.get_cplex().solution.pool.filter
+    for i in range(flt.get_num()):
+        print('  filter %s: type %s' % (flt.get_names(i), flt.get_type(i)))
+
+
+if __name__ == "__main__":
+    rnd = random.Random(42)
+    Items = range(20)
+    weight = [rnd.randint(5, 30) for i in Items]
+    profit = [rnd.randint(5, 40) for i in Items]
+    capacity = 150
+
+    with Model(name='knapsack') as m:
+        take = m.binary_var_list(Items, name='take')
+        load = m.sum(weight[i] * take[i] for i in Items)
+        m.add_constraint(load <= capacity)
+        m.maximize(m.sum(profit[i] * take[i] for i in Items))
+        sol = m.solve()
+        assert sol is not None
+        print('Optimal profit: %f' % sol.get_objective_value())
+
+        if len(sys.argv) > 1:
+            m.get_cplex().solution.pool.filter.read(sys.argv[1])
+        else:
+            # Only accept solutions that differ from the optimal one in at
+            # least 3 items and that use at least 90% of the capacity.
+            add_diversity_filter(m, {v: sol.get_value(v) for v in take},
+                                 3, len(Items), name='diverse')
+            add_range_filter(m, load, 0.9 * capacity, capacity,
+                             name='loaded')
+            # Show the filters in the file format that read() accepts.
+            with tempfile.TemporaryDirectory() as tmp:
+                fltfile = os.path.join(tmp, 'knapsack.flt')
+                m.get_cplex().solution.pool.filter.write(fltfile)
+                with open(fltfile, 'r') as f:
+                    print(f.read().rstrip())
+        print('Filters:')
+        print_filters(m)
+
+        m.parameters.mip.limits.populate = 10
+        pool = m.populate_solution_pool()
+        if pool is None:
+            print('Populate found no solutions that pass the filters')
+            pool = []
+        for s in pool:
+            print('  profit %g, load %g, items %s' %
+                  (s.get_objective_value(), s.get_value(load),
+                   [i for i in Items if s.get_value(take[i]) > 0.5]))
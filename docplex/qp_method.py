@@ -0,0 +1,119 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to choose between the simplex and barrier optimizers
+# for a continuous QP from simple model statistics, and how to run
+# crossover only if a basic solution is needed.
+#
+# The rule used here is a starting point, not a replacement for testing on
+# your own models:
+# - small QPs are solved with the dual simplex optimizer. It is fast on
+#   small models and returns a basis, which is needed for warm starts and
+#   for sensitivity analysis,
+# - QPs with a dense Q matrix are solved with the primal simplex
+#   optimizer, since dense Q makes the barrier factorization expensive,
+# - all other QPs are solved with the barrier optimizer.
+# After barrier, crossover computes a basic solution. This can take as long
+# as the barrier itself, so it is switched off (barrier.crossover = -1)
+# unless a basis is requested.
+#
+# solve_qp() returns the solution together with the Decision that was made,
+# so that it can be logged with the results.
+import random
+from collections import namedtuple
+
+from docplex.mp.model import Model
+
+Decision = namedtuple('Decision', ['method', 'qpmethod', 'crossover',
+                                   'reason'])
+Stats = namedtuple('Stats', ['variables', 'rows', 'nonzeros', 'q_nonzeros',
+                             'q_density'])
+
+# Values of the qpmethod and barrier.crossover parameters.
+DUAL_SIMPLEX = 2
+PRIMAL_SIMPLEX = 1
+BARRIER = 4
+NO_CROSSOVER = -1
+AUTO_CROSSOVER = 0
+
+
+def qp_stats(mdl):
+    """Return the Stats of the QP MDL."""
+    n = mdl.number_of_variables
+    nonzeros = 0
+    for ct in mdl.iter_linear_constraints():
+        expr = ct.get_left_expr() - ct.get_right_expr()
+        nonzeros += sum(1 for v, c in expr.iter_terms() if c != 0)
+    obj = mdl.objective_expr
+    q = sum(1 for v1, v2, c in obj.iter_quad_triplets() if c != 0) \
+        if obj.is_quad_expr() else 0
+    # Q is symmetric: compare with the size of its upper triangle.
+    density = float(q) / (n * (n + 1) / 2) if n > 0 else 0.0
+    return Stats(n, mdl.number_of_linear_constraints, nonzeros, q, density)
+
+
+def choose(stats, need_basis=False, small=1000, dense=0.2):
+    """Return the Decision for a QP with the Stats STATS."""
+    if stats.variables + stats.rows <= small:
+        return Decision('dual simplex', DUAL_SIMPLEX, None,
+                        '%d variables and %d rows' %
+                        (stats.variables, stats.rows))
+    if stats.q_density >= dense:
+        return Decision('primal simplex', PRIMAL_SIMPLEX, None,
+                        'Q is %.0f%% dense' % (100 * stats.q_density))
+    crossover = AUTO_CROSSOVER if need_basis else NO_CROSSOVER
+    return Decision('barrier', BARRIER, crossover,
+                    'large and sparse: %d variables, Q %.1f%% dense, '
+                    'crossover %s' % (stats.variables, 100 * stats.q_density,
+                                      'on' if need_basis else 'off'))
+
+
+def solve_qp(mdl, need_basis=False, **kwargs):
+    """Solve the continuous QP MDL with the optimizer chosen by choose().
+    Returns (solution, decision). The parameters are restored afterwards."""
+    if mdl.number_of_integer_vars + mdl.number_of_binary_vars > 0:
+        raise Exception('Model %s is not continuous' % mdl.name)
+    decision = choose(qp_stats(mdl), need_basis)
+    qpmethod = mdl.parameters.qpmethod
+    crossover = mdl.parameters.barrier.crossover
+    saved = qpmethod.get(), crossover.get()
+    try:
+        qpmethod.set(decision.qpmethod)
+        if decision.crossover is not None:
+            crossover.set(decision.crossover)
+        return mdl.solve(**kwargs), decision
+    finally:
+        qpmethod.set(saved[0])
+        crossover.set(saved[1])
+
+
+def portfolio(n, seed=1):
+    """Build a mean-variance portfolio model with N assets and a factor
+    covariance structure, which gives a sparse Q."""
+    rnd = random.Random(seed)
+    mdl = Model(name='portfolio_%d' % n)
+    w = mdl.continuous_var_list(n, ub=0.1, name='w')
+    exposure = [rnd.uniform(-1, 1) for i in range(n)]
+    f = mdl.continuous_var(lb=-mdl.infinity, name='factor')
+    mdl.add_constraint(f == mdl.sum(e * wi for e, wi in zip(exposure, w)))
+    mdl.add_constraint(mdl.sum(w) == 1)
+    ret = [rnd.uniform(0.02, 0.1) for i in range(n)]
+    risk = [rnd.uniform(0.01, 0.05) for i in range(n)]
+    mdl.minimize(f * f + mdl.sum(r * wi * wi for r, wi in zip(risk, w)) -
+                 mdl.sum(r * wi for r, wi in zip(ret, w)))
+    return mdl
+
+
+if __name__ == "__main__":
+    for n, need_basis in ((50, False), (5000, False), (5000, True)):
+        with portfolio(n) as m:
+            sol, decision = solve_qp(m, need_basis)
+            print('%d assets, basis %s: %s (%s)' %
+                  (n, 'needed' if need_basis else 'not needed',
+                   decision.method, decision.reason))
+            if sol is not None:
+                print('  objective %g in %.2f s' %
+                      (sol.objective_value, m.solve_details.time))
@@ -0,0 +1,138 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to detect constraints that are implied by the other
+# constraints of a model.
+#
+# An inequality expr <= 0 is redundant if the maximum of expr subject to all
+# other constraints is <= 0. Many rows can already be identified from the
+# variable bounds alone; the remaining ones need an LP solve each. For a MIP
+# the LP relaxation is used: a row that is redundant for the relaxation is
+# also redundant for the MIP (the converse is not true, so some redundant
+# rows of a MIP may be missed).
+#
+# Redundant rows are removed one at a time while the analysis proceeds, so
+# that of two identical rows only one is reported. The result is grouped by
+# constraint family (the constraint name up to the first '_') and redundant
+# rows can be removed before exporting the model.
+#
+# Usage: python redundancy.py [LP file]
+#        The reduced model is exported to the LP file if one is given, and
+#        printed otherwise.
+import sys
+
+from docplex.mp.model import Model
+from docplex.mp.relax_linear import LinearRelaxer
+
+
+def default_family(name):
+    return name.split('_')[0] if name else '<unnamed>'
+
+
+def normalize(ct):
+    """Return EXPR such that CT is EXPR <= 0, or None if CT is not an
+    inequality."""
+    sense = ct.sense.name
+    if sense == 'EQ':
+        return None
+    expr = ct.get_left_expr() - ct.get_right_expr()
+    return expr if sense == 'LE' else -expr
+
+
+def bound_max(expr):
+    """Maximum of the linear expression EXPR over the variable bounds."""
+    hi = expr.get_constant()
+    for v, coef in expr.iter_terms():
+        hi += coef * (v.ub if coef > 0 else v.lb)
+    return hi
+
+
+def find_redundant(mdl, tol=1e-6):
+    """Find inequalities of MDL that are implied by the other constraints.
+    MDL is modified: redundant rows are removed from it. Returns the list of
+    names of redundant rows."""
+    objective = mdl.objective_expr
+    sense = mdl.objective_sense
+    redundant = list()
+    try:
+        for ct in list(mdl.iter_linear_constraints()):
+            if not hasattr(ct, 'get_left_expr'):
+                continue
+            expr = normalize(ct)
+            if expr is None:
+                continue
+            name = ct.name
+            if bound_max(expr) <= tol:
+                mdl.remove_constraint(ct)
+                redundant.append(name)
+                continue
+            mdl.remove_constraint(ct)
+            mdl.maximize(expr)
+            sol = mdl.solve()
+            if sol is not None and sol.get_objective_value() <= tol:
+                redundant.append(name)
+            else:
+                # Not redundant (or unbounded without it): put it back.
+                mdl.add_constraint(expr <= 0, name)
+    finally:
+        mdl.set_objective(sense, objective)
+    return redundant
+
+
+def analyze(mdl, family=default_family, remove=False, tol=1e-6):
+    """Find the redundant inequalities of MDL and group them by family.
+    If REMOVE is true they are also removed from MDL. Returns a dictionary
+    mapping families to lists of constraint names."""
+    if mdl.number_of_integer_vars + mdl.number_of_binary_vars > 0:
+        work = LinearRelaxer.make_relaxed_model(mdl)
+    else:
+        work = mdl.clone()
+    names = find_redundant(work, tol)
+    work.end()
+    groups = dict()
+    for name in names:
+        groups.setdefault(family(name), []).append(name)
+    if remove:
+        mdl.remove_constraints([mdl.get_constraint_by_name(name)
+                                for name in names])
+    return groups
+
+
+if __name__ == "__main__":
+    Products = range(4)
+    Resources = range(3)
+    use = [[2, 1, 3, 1], [1, 2, 1, 2], [1, 1, 1, 1]]
+    capacity = [40, 40, 30]
+    profit = [5, 4, 6, 3]
+
+    with Model(name='production') as m:
+        x = m.integer_var_list(Products, ub=20, name='x')
+        for r in Resources:
+            m.add_constraint(m.sum(use[r][p] * x[p] for p in Products)
+                             <= capacity[r], 'capacity_%d' % r)
+        # Naively generated rows: each product is limited by every resource
+        # separately, and by its own upper bound once more.
+        for r in Resources:
+            for p in Products:
+                m.add_constraint(use[r][p] * x[p] <= capacity[r],
+                                 'single_%d_%d' % (r, p))
+        for p in Products:
+            m.add_constraint(x[p] <= 25, 'limit_%d' % p)
+        # Implied by capacity_0 + capacity_1.
+        m.add_constraint(m.sum((use[0][p] + use[1][p]) * x[p] for p in Products)
+                         <= 100, 'total')
+        m.maximize(m.sum(profit[p] * x[p] for p in Products))
+
+        print('%d constraints' % m.number_of_constraints)
+        groups = analyze(m, remove=True)
+        for fam in sorted(groups):
+            print('%s: %d redundant (%s)' % (fam, len(groups[fam]),
+                                             ', '.join(groups[fam])))
+        print('%d constraints after removal' % m.number_of_constraints)
+        if len(sys.argv) > 1:
+            m.export_as_lp(sys.argv[1])
+        else:
+            print(m.export_as_lp_string())
@@ -0,0 +1,246 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to report coefficient ranges of a model per family of
+# rows and columns, and how to scale families by hand.
+#
+# A family is the name of a constraint or variable up to the first '_', so
+# all rows 'capacity_1', 'capacity_2', ... belong to family 'capacity'. A
+# model whose coefficients span many orders of magnitude is often badly
+# scaled because of a few families that use other units than the rest
+# (grams instead of tons, cents instead of millions).
+#
+# report() lists the smallest and largest absolute matrix coefficient of
+# each family and their ratio. suggest() computes one factor per family by
+# geometric mean scaling: the factors are chosen such that the coefficients
+# of each family are centered around 1, alternating between rows and
+# columns. Factors are rounded to powers of 2 so that scaling introduces no
+# rounding errors. Columns of families with integer variables are not
+# scaled, since that would change their integrality.
+#
+# suggest_objective() computes a factor o for the objective in the same
+# way, so that objective coefficients of (say) millions of dollars do not
+# dominate the reduced costs.
+#
+# apply() scales the model in place: each row i is multiplied by r[i], each
+# column j is replaced by x[j] = c[j] * y[j] and the objective is
+# multiplied by o. The factors can also be chosen by hand, for example to
+# state a model in natural units and let apply() convert it. unscale() maps
+# the solution of the scaled model back to the original one:
+#   objective = objective' / o, x[j] = c[j] * y[j],
+#   duals pi[i] = r[i] * pi'[i] / o, reduced costs d[j] = d'[j] / (c[j] o),
+#   slacks s[i] = s'[i] / r[i].
+# CPLEX scales the model internally as well (parameter read.scale), so
+# scaling by hand mostly helps to find the families that should be stated
+# in other units.
+#
+# Only linear models (LP and MIP) are supported. The model is read with the
+# CPLEX Python API, so the CPLEX runtime is needed.
+#
+# Usage: python scaling_report.py <model file> [apply]
+#        With 'apply', the suggested factors are applied, the scaled model
+#        is solved and its solution is unscaled.
+import math
+import sys
+from collections import namedtuple
+
+import cplex
+
+FamilyRange = namedtuple('FamilyRange', ['kind', 'family', 'count',
+                                         'min', 'max', 'ratio'])
+Scaling = namedtuple('Scaling', ['rows', 'cols', 'obj'])
+
+
+def default_family(name):
+    return name.split('_')[0] if name else '<unnamed>'
+
+
+def _check_linear(cpx):
+    if cpx.quadratic_constraints.get_num() > 0 or \
+       cpx.objective.get_num_quadratic_nonzeros() > 0 or \
+       cpx.indicator_constraints.get_num() > 0:
+        raise Exception('Only linear models can be scaled')
+
+
+def _entries(cpx):
+    """Return the nonzeros of CPX as a list of (row, column, value)."""
+    entries = list()
+    if cpx.linear_constraints.get_num() > 0:
+        for i, sp in enumerate(cpx.linear_constraints.get_rows()):
+            entries.extend((i, j, a) for j, a in zip(sp.ind, sp.val) if a != 0)
+    return entries
+
+
+def _families(names, family):
+    return [family(name) for name in names]
+
+
+def _ranges(kind, fams, values):
+    """Aggregate (index, |value|) pairs into one FamilyRange per family."""
+    lo, hi, count = dict(), dict(), dict()
+    for k, a in values:
+        f = fams[k]
+        lo[f] = min(lo.get(f, a), a)
+        hi[f] = max(hi.get(f, a), a)
+    for f in fams:
+        count[f] = count.get(f, 0) + 1
+    return [FamilyRange(kind, f, count[f], lo[f], hi[f], hi[f] / lo[f])
+            for f in sorted(lo)]
+
+
+def report(cpx, family=default_family):
+    """Return a list of FamilyRanges with the absolute coefficient range of
+    each row and column family of CPX."""
+    rowfam = _families(cpx.linear_constraints.get_names(), family) \
+        if cpx.linear_constraints.get_num() > 0 else []
+    colfam = _families(cpx.variables.get_names(), family) \
+        if cpx.variables.get_num() > 0 else []
+    entries = _entries(cpx)
+    return _ranges('row', rowfam, [(i, abs(a)) for i, j, a in entries]) + \
+        _ranges('column', colfam, [(j, abs(a)) for i, j, a in entries])
+
+
+def _power_of_2(x):
+    return 2.0 ** round(math.log(x, 2))
+
+
+def suggest(cpx, family=default_family, passes=4):
+    """Suggest one scaling factor per row and column family of CPX by
+    geometric mean scaling. Returns (row factors, column factors), both
+    dictionaries mapping families to factors."""
+    _check_linear(cpx)
+    rowfam = _families(cpx.linear_constraints.get_names(), family) \
+        if cpx.linear_constraints.get_num() > 0 else []
+    names = cpx.variables.get_names() if cpx.variables.get_num() > 0 else []
+    colfam = _families(names, family)
+    fixed = set()
+    if cpx.get_problem_type() != cpx.problem_type.LP and len(names) > 0:
+        fixed = set(f for f, t in zip(colfam, cpx.variables.get_types())
+                    if t != 'C')
+    entries = _entries(cpx)
+    rowfac = {f: 1.0 for f in rowfam}
+    colfac = {f: 1.0 for f in colfam}
+    for p in range(passes):
+        for fams, fac, pos, other, otherfac in \
+                ((rowfam, rowfac, 0, colfam, colfac),
+                 (colfam, colfac, 1, rowfam, rowfac)):
+            lo, hi = dict(), dict()
+            for e in entries:
+                f = fams[e[pos]]
+                a = abs(e[2]) * otherfac[other[e[1 - pos]]]
+                lo[f] = min(lo.get(f, a), a)
+                hi[f] = max(hi.get(f, a), a)
+            for f in lo:
+                if fac is colfac and f in fixed:
+                    continue
+                fac[f] = _power_of_2(1.0 / math.sqrt(lo[f] * hi[f]))
+    return rowfac, colfac
+
+
+def suggest_objective(cpx, colfac, family=default_family):
+    """Suggest a factor for the objective of CPX, given the column factors
+    COLFAC. Returns 1 if the objective is zero."""
+    if cpx.variables.get_num() == 0:
+        return 1.0
+    coefs = [abs(o) * colfac.get(f, 1.0) for o, f in
+             zip(cpx.objective.get_linear(),
+                 _families(cpx.variables.get_names(), family)) if o != 0]
+    if len(coefs) == 0:
+        return 1.0
+    return _power_of_2(1.0 / math.sqrt(min(coefs) * max(coefs)))
+
+
+def apply(cpx, rowfac, colfac, objfac=1.0, family=default_family):
+    """Scale CPX in place by the family factors ROWFAC and COLFAC (as
+    returned by suggest()) and the objective factor OBJFAC. Returns the
+    Scaling needed by unscale()."""
+    _check_linear(cpx)
+    nrows = cpx.linear_constraints.get_num()
+    ncols = cpx.variables.get_num()
+    r = [rowfac.get(f, 1.0) for f in
+         _families(cpx.linear_constraints.get_names() if nrows > 0 else [],
+                   family)]
+    c = [colfac.get(f, 1.0) for f in
+         _families(cpx.variables.get_names() if ncols > 0 else [], family)]
+    entries = _entries(cpx)
+    if len(entries) > 0:
+        cpx.linear_constraints.set_coefficients(
+            [(i, j, a * r[i] * c[j]) for i, j, a in entries])
+    if nrows > 0:
+        cpx.linear_constraints.set_rhs(
+            [(i, b * r[i]) for i, b in
+             enumerate(cpx.linear_constraints.get_rhs())])
+        cpx.linear_constraints.set_range_values(
+            [(i, v * r[i]) for i, v in
+             enumerate(cpx.linear_constraints.get_range_values())])
+    if ncols > 0:
+        inf = cplex.infinity
+        cpx.objective.set_linear(
+            [(j, o * c[j] * objfac) for j, o in
+             enumerate(cpx.objective.get_linear())])
+        cpx.objective.set_offset(cpx.objective.get_offset() * objfac)
+        cpx.variables.set_lower_bounds(
+            [(j, lb / c[j] if lb > -inf else lb) for j, lb in
+             enumerate(cpx.variables.get_lower_bounds())])
+        cpx.variables.set_upper_bounds(
+            [(j, ub / c[j] if ub < inf else ub) for j, ub in
+             enumerate(cpx.variables.get_upper_bounds())])
+    return Scaling(r, c, objfac)
+
+
+def unscale(cpx, scaling):
+    """Return the solution of the model scaled by SCALING in terms of the
+    original model: a dictionary with the values, slacks and, for LPs, the
+    duals and reduced costs."""
+    r, c, o = scaling
+    sol = cpx.solution
+    result = {'objective': sol.get_objective_value() / o,
+              'values': [cj * y for cj, y in zip(c, sol.get_values())],
+              'slacks': [s / ri for ri, s in zip(r, sol.get_linear_slacks())]}
+    if cpx.get_problem_type() == cpx.problem_type.LP:
+        result['duals'] = [ri * pi / o for ri, pi in
+                           zip(r, sol.get_dual_values())]
+        result['reduced_costs'] = [d / (cj * o) for cj, d in
+                                   zip(c, sol.get_reduced_costs())]
+    return result
+
+
+def print_ranges(ranges):
+    for fr in ranges:
+        print('%-6s %-20s %6d  %10.3g %10.3g %10.3g' % fr)
+
+
+if __name__ == "__main__":
+    if len(sys.argv) not in (2, 3):
+        print('Usage: python scaling_report.py <model file> [apply]')
+        sys.exit(2)
+    cpx = cplex.Cplex(sys.argv[1])
+    cpx.set_results_stream(None)
+    print('%-6s %-20s %6s  %10s %10s %10s' %
+          ('kind', 'family', 'count', 'min', 'max', 'ratio'))
+    print_ranges(report(cpx))
+    rowfac, colfac = suggest(cpx)
+    objfac = suggest_objective(cpx, colfac)
+    print('Suggested factors:')
+    for kind, fac in (('row', rowfac), ('column', colfac)):
+        for f in sorted(fac):
+            if fac[f] != 1.0:
+                print('  %-6s %-20s %g' % (kind, f, fac[f]))
+    print('  objective %g' % objfac)
+    if len(sys.argv) == 3 and sys.argv[2] == 'apply':
+        scaling = apply(cpx, rowfac, colfac, objfac)
+        print('Ranges after scaling:')
+        print_ranges(report(cpx))
+        cpx.solve()
+        print('Status: %s' % cpx.solution.get_status_string())
+        if cpx.solution.is_primal_feasible():
+            result = unscale(cpx, scaling)
+            print('Objective: %g' % result['objective'])
+            names = cpx.variables.get_names()
+            for name, x in zip(names, result['values']):
+                if abs(x) > 1e-9:
+                    print('  %s = %g' % (name, x))
+    cpx.end()
@@ -0,0 +1,162 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to analyze a schedule after solving.
+#
+# The analysis works on a plain list of tasks, so it can be used for
+# schedules from CP Optimizer as well as from MIP models:
+# - profile() computes the usage of a resource over time as a step
+#   function,
+# - peaks() finds the time windows in which the usage is above a level,
+# - idle_times() summarizes the busy and idle time of each resource and
+#   lists the gaps,
+# - critical_path() finds a chain of tasks that determines the makespan:
+#   each task in the chain ends exactly when the next one starts, either
+#   because of a precedence or because they use the same resource. Delaying
+#   any of them delays the end of the schedule.
+# The results can be written as CSV.
+#
+# The schedule analyzed here is the flexible job shop of schedule_repair.py.
+#
+# Usage: python schedule_analysis.py [output directory]
+#        The files usage.csv, gaps.csv and profile.csv are written to the
+#        directory if one is given.
+import csv
+import os
+import sys
+from collections import namedtuple
+
+from schedule_repair import JOBS, build, makespan, read_schedule
+
+Task = namedtuple('Task', ['name', 'resource', 'start', 'end', 'demand'])
+Gap = namedtuple('Gap', ['resource', 'start', 'end'])
+Usage = namedtuple('Usage', ['resource', 'busy', 'idle', 'utilization'])
+
+
+def profile(tasks, resource=None):
+    """Return the usage of RESOURCE (all resources if None) as a list of
+    (time, level) steps: the level holds from time until the next step."""
+    events = dict()
+    for t in tasks:
+        if resource is None or t.resource == resource:
+            events[t.start] = events.get(t.start, 0) + t.demand
+            events[t.end] = events.get(t.end, 0) - t.demand
+    steps = list()
+    level = 0
+    for time in sorted(events):
+        level += events[time]
+        if len(steps) > 0 and steps[-1][1] == level:
+            continue
+        steps.append((time, level))
+    return steps
+
+
+def peaks(steps, level):
+    """Return the (start, end, max level) windows in which the profile STEPS
+    is above LEVEL."""
+    windows = list()
+    current = None
+    for (time, value) in steps:
+        if value > level:
+            if current is None:
+                current = [time, None, value]
+            else:
+                current[2] = max(current[2], value)
+        elif current is not None:
+            current[1] = time
+            windows.append(tuple(current))
+            current = None
+    return windows
+
+
+def idle_times(tasks, horizon_start, horizon_end):
+    """Return (usages, gaps): the busy and idle time of each resource between
+    HORIZON_START and HORIZON_END, and the idle gaps of each resource."""
+    usages = list()
+    gaps = list()
+    for resource in sorted(set(t.resource for t in tasks)):
+        # Start of the current idle period, None while the resource is busy.
+        last = horizon_start
+        for time, level in profile(tasks, resource):
+            if level > 0 and last is not None:
+                if time > last:
+                    gaps.append(Gap(resource, last, time))
+                last = None
+            elif level == 0 and last is None:
+                last = time
+        if last is None:
+            last = horizon_end
+        if last < horizon_end:
+            gaps.append(Gap(resource, last, horizon_end))
+        idle = sum(g.end - g.start for g in gaps if g.resource == resource)
+        busy = (horizon_end - horizon_start) - idle
+        usages.append(Usage(resource, busy, idle,
+                            busy / float(horizon_end - horizon_start)))
+    return usages, gaps
+
+
+def critical_path(tasks, precedences):
+    """Return a critical path as a list of tasks. PRECEDENCES is a list of
+    (before, after) task names."""
+    byname = {t.name: t for t in tasks}
+    preds = dict()
+    for a, b in precedences:
+        preds.setdefault(b, []).append(byname[a])
+    end = max(t.end for t in tasks)
+    current = next(t for t in tasks if t.end == end)
+    path = [current]
+    while current.start > min(t.start for t in tasks):
+        candidates = [t for t in preds.get(current.name, [])
+                      if t.end == current.start]
+        candidates += [t for t in tasks if t.resource == current.resource and
+                       t.end == current.start and t is not current]
+        if len(candidates) == 0:
+            # The task could start earlier: the chain starts here.
+            break
+        current = candidates[0]
+        path.append(current)
+    path.reverse()
+    return path
+
+
+def write_csv(f, rows, fields):
+    w = csv.writer(f)
+    w.writerow(fields)
+    w.writerows(rows)
+
+
+if __name__ == "__main__":
+    mdl, ops, alts = build(JOBS)
+    mdl.add(mdl.minimize(makespan(mdl, ops)))
+    sol = mdl.solve(TimeLimit=10, LogVerbosity='Quiet')
+    if not sol:
+        print('No schedule found')
+        sys.exit(1)
+    schedule = read_schedule(sol, alts)
+    tasks = [Task('J%d_O%d' % (j, o), 'M%d' % m, s, e, 1)
+             for (j, o), (m, s, e) in sorted(schedule.items())]
+    precedences = [('J%d_O%d' % (j, o - 1), 'J%d_O%d' % (j, o))
+                   for j, job in enumerate(JOBS) for o in range(1, len(job))]
+    horizon = max(t.end for t in tasks)
+
+    usages, gaps = idle_times(tasks, 0, horizon)
+    print('Makespan %d' % horizon)
+    for u in usages:
+        print('%s: busy %d, idle %d, utilization %.0f%%' %
+              (u.resource, u.busy, u.idle, 100 * u.utilization))
+    # Windows in which all machines are busy at the same time.
+    for start, end, level in peaks(profile(tasks), len(usages) - 1):
+        print('All machines busy in [%d, %d)' % (start, end))
+    print('Critical path: %s' % ' -> '.join(t.name for t in
+                                           critical_path(tasks, precedences)))
+    if len(sys.argv) > 1:
+        for filename, rows, fields in (
+                ('usage.csv', usages, Usage._fields),
+                ('gaps.csv', gaps, Gap._fields),
+                ('profile.csv', profile(tasks), ['time', 'level'])):
+            with open(os.path.join(sys.argv[1], filename), 'w',
+                      newline='') as f:
+                write_csv(f, rows, fields)
@@ -0,0 +1,133 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to export a CP Optimizer schedule to calendar and web
+# formats.
+#
+# A CP Optimizer schedule uses integer time units. To export it, the start
+# of the horizon is mapped to a date and time and each unit to a duration
+# (here one hour). The schedule is then written as
+# - an iCalendar file (RFC 5545) with one event per task, which can be
+#   imported into calendar applications,
+# - a timeline JSON file with groups (one per resource) and items (one per
+#   task) in the format used by the vis.js Timeline component.
+# Absent optional intervals are not exported.
+#
+# The example is a small house building problem where each task is done
+# by one of several workers.
+#
+# Usage: python schedule_export.py [output directory]
+#        The files house.ics and house.json are written to the directory if
+#        one is given, and printed otherwise.
+import datetime
+import json
+import os
+import sys
+
+from docplex.cp.model import CpoModel
+
+
+def extract_tasks(sol, tasks):
+    """Get (name, resource, start, end) of the present intervals in the
+    solution SOL. TASKS is a list of (interval variable, resource name)."""
+    result = list()
+    for itv, resource in tasks:
+        s = sol.get_var_solution(itv)
+        if s is not None and s.is_present():
+            result.append((itv.get_name(), resource, s.get_start(), s.get_end()))
+    return result
+
+
+def to_datetime(origin, unit, t):
+    return origin + t * unit
+
+
+def ical_escape(text):
+    return text.replace('\\', '\\\\').replace(';', '\\;').replace(',', '\\,') \
+        .replace('\n', '\\n')
+
+
+def write_ical(f, tasks, origin, unit, calendar='schedule'):
+    """Write TASKS (as returned by extract_tasks()) as iCalendar events.
+    ORIGIN is the datetime of time 0 and UNIT the timedelta of one time
+    unit."""
+    fmt = '%Y%m%dT%H%M%S'
+    stamp = datetime.datetime.now(datetime.timezone.utc).strftime(fmt) + 'Z'
+    lines = ['BEGIN:VCALENDAR', 'VERSION:2.0',
+             'PRODID:-//cplex examples//%s//EN' % ical_escape(calendar)]
+    for name, resource, start, end in tasks:
+        lines += ['BEGIN:VEVENT',
+                  'UID:%s-%s@%s' % (name, start, calendar),
+                  'DTSTAMP:' + stamp,
+                  'DTSTART:' + to_datetime(origin, unit, start).strftime(fmt),
+                  'DTEND:' + to_datetime(origin, unit, end).strftime(fmt),
+                  'SUMMARY:' + ical_escape(name),
+                  'LOCATION:' + ical_escape(resource),
+                  'END:VEVENT']
+    lines.append('END:VCALENDAR')
+    f.write('\r\n'.join(lines) + '\r\n')
+
+
+def write_timeline(f, tasks, origin, unit):
+    """Write TASKS as timeline JSON with one group per resource."""
+    resources = sorted(set(resource for name, resource, s, e in tasks))
+    groups = [{'id': r, 'content': r} for r in resources]
+    items = [{'id': k, 'group': resource, 'content': name,
+              'start': to_datetime(origin, unit, start).isoformat(),
+              'end': to_datetime(origin, unit, end).isoformat()}
+             for k, (name, resource, start, end) in enumerate(tasks)]
+    json.dump({'groups': groups, 'items': items}, f, indent=2)
+
+
+if __name__ == "__main__":
+    Workers = ['Joe', 'Jack', 'Jim']
+    # task: (duration, workers who can do it)
+    Tasks = {
+        'masonry': (35, ['Joe', 'Jack']), 'carpentry': (15, ['Joe', 'Jim']),
+        'plumbing': (40, ['Jack']), 'ceiling': (15, ['Jim', 'Jack']),
+        'roofing': (5, ['Joe', 'Jim']), 'painting': (10, ['Jim', 'Jack']),
+        'windows': (5, ['Joe', 'Jim']), 'facade': (10, ['Joe', 'Jack']),
+        'garden': (5, ['Jack', 'Jim']), 'moving': (5, ['Joe', 'Jim']),
+    }
+    Precedences = [('masonry', 'carpentry'), ('masonry', 'plumbing'),
+                   ('masonry', 'ceiling'), ('carpentry', 'roofing'),
+                   ('ceiling', 'painting'), ('roofing', 'windows'),
+                   ('roofing', 'facade'), ('plumbing', 'facade'),
+                   ('roofing', 'garden'), ('plumbing', 'garden'),
+                   ('windows', 'moving'), ('facade', 'moving'),
+                   ('garden', 'moving'), ('painting', 'moving')]
+
+    mdl = CpoModel(name='house')
+    itv = {t: mdl.interval_var(size=d, name=t) for t, (d, ws) in Tasks.items()}
+    alt = {(t, w): mdl.interval_var(optional=True, name='%s_%s' % (t, w))
+           for t, (d, ws) in Tasks.items() for w in ws}
+    for t, (d, ws) in Tasks.items():
+        mdl.add(mdl.alternative(itv[t], [alt[t, w] for w in ws]))
+    for a, b in Precedences:
+        mdl.add(mdl.end_before_start(itv[a], itv[b]))
+    for w in Workers:
+        mdl.add(mdl.no_overlap([alt[t, w] for t, (d, ws) in Tasks.items()
+                                if w in ws]))
+    mdl.add(mdl.minimize(mdl.max([mdl.end_of(itv[t]) for t in Tasks])))
+
+    sol = mdl.solve(TimeLimit=10, LogVerbosity='Quiet')
+    if sol:
+        tasks = extract_tasks(sol, [(alt[t, w], w) for (t, w) in alt])
+        origin = datetime.datetime(2026, 3, 2, 8, 0)
+        unit = datetime.timedelta(hours=1)
+        if len(sys.argv) > 1:
+            with open(os.path.join(sys.argv[1], 'house.ics'), 'w',
+                      newline='') as f:
+                write_ical(f, tasks, origin, unit, calendar='house')
+            with open(os.path.join(sys.argv[1], 'house.json'), 'w') as f:
+                write_timeline(f, tasks, origin, unit)
+            print('Exported %d tasks to house.ics and house.json' % len(tasks))
+        else:
+            write_ical(sys.stdout, tasks, origin, unit, calendar='house')
+            write_timeline(sys.stdout, tasks, origin, unit)
+            print()
+    else:
+        print('No solution found')
@@ -0,0 +1,158 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to repair a CP Optimizer schedule after a disruption.
+#
+# When a schedule is executed, things go wrong: a machine breaks down or an
+# operation takes longer than planned. The schedule must then be repaired
+# at the current time NOW:
+# - operations that started before NOW are locked: they keep their machine
+#   and their start time,
+# - all other operations cannot start before NOW,
+# - the disruption is added to the model (machine down during a time
+#   window, longer duration),
+# - the objective is the makespan plus a stability penalty for each
+#   operation that moves or changes its machine, so that the repaired
+#   schedule stays close to the one people are already working with,
+# - the old schedule is given as starting point, so the search starts from
+#   a schedule that is close to a good repair.
+#
+# The example is a flexible job shop: each job is a sequence of operations
+# and each operation can be done on one of several machines.
+from docplex.cp.model import CpoModel
+from docplex.cp.solution import CpoModelSolution
+
+# Jobs: lists of operations, each a list of (machine, duration).
+JOBS = [
+    [[(0, 3), (1, 5)], [(1, 4), (2, 4)], [(2, 2)]],
+    [[(1, 2)], [(0, 4), (2, 3)], [(0, 3), (1, 3)]],
+    [[(2, 4), (0, 6)], [(1, 3)], [(0, 2), (2, 2)]],
+    [[(0, 5)], [(2, 3), (1, 4)], [(1, 2), (0, 3)]],
+]
+MACHINES = range(3)
+
+
+def build(jobs, durations=None):
+    """Build the flexible job shop model. DURATIONS optionally overrides the
+    duration of operations by (job, operation, machine).
+    Returns (model, operations, alternatives)."""
+    durations = durations or dict()
+    mdl = CpoModel(name='jobshop')
+    ops = dict()
+    alts = dict()
+    for j, job in enumerate(jobs):
+        for o, choices in enumerate(job):
+            ops[j, o] = mdl.interval_var(name='J%d_O%d' % (j, o))
+            for m, d in choices:
+                d = durations.get((j, o, m), d)
+                alts[j, o, m] = mdl.interval_var(optional=True, size=d,
+                                                 name='J%d_O%d_M%d' % (j, o, m))
+            mdl.add(mdl.alternative(ops[j, o], [alts[j, o, m]
+                                                for m, d in choices]))
+            if o > 0:
+                mdl.add(mdl.end_before_start(ops[j, o - 1], ops[j, o]))
+    for m in MACHINES:
+        mdl.add(mdl.no_overlap([a for (j, o, mm), a in alts.items() if mm == m]))
+    return mdl, ops, alts
+
+
+def add_downtime(mdl, alts, downtime, exempt=()):
+    """Forbid operations on machine M during the DOWNTIME window (M, start,
+    end), except for the operations (job, operation) in EXEMPT."""
+    m, start, end = downtime
+    for (j, o, mm), a in alts.items():
+        if mm == m and (j, o) not in exempt:
+            mdl.add(mdl.logical_or(mdl.end_of(a, start) <= start,
+                                   mdl.start_of(a, end) >= end))
+
+
+def makespan(mdl, ops):
+    return mdl.max([mdl.end_of(itv) for itv in ops.values()])
+
+
+def read_schedule(sol, alts):
+    """Get the schedule as a dictionary (job, operation) -> (machine, start,
+    end)."""
+    schedule = dict()
+    for (j, o, m), a in alts.items():
+        s = sol.get_var_solution(a)
+        if s.is_present():
+            schedule[j, o] = (m, s.get_start(), s.get_end())
+    return schedule
+
+
+def repair(jobs, old, now, durations=None, downtime=None, move_weight=1,
+           machine_weight=5, time_limit=10):
+    """Repair the schedule OLD (as returned by read_schedule()) at time NOW
+    after a disruption. Returns the new schedule or None."""
+    mdl, ops, alts = build(jobs, durations)
+    locked = [(j, o) for (j, o), (m, s, e) in old.items() if s < now]
+    if downtime is not None:
+        # Locked operations are already running, the breakdown is assumed
+        # to interrupt them without moving them.
+        add_downtime(mdl, alts, downtime, exempt=locked)
+    penalties = list()
+    start = CpoModelSolution()
+    for (j, o), (m, s, e) in old.items():
+        if (j, o) in locked:
+            # Started (or done): lock machine and start time.
+            mdl.add(mdl.presence_of(alts[j, o, m]) == 1)
+            mdl.add(mdl.start_of(ops[j, o]) == s)
+        else:
+            mdl.add(mdl.start_of(ops[j, o]) >= now)
+            penalties.append(move_weight * mdl.abs(mdl.start_of(ops[j, o]) - s))
+            penalties.append(machine_weight *
+                             (1 - mdl.presence_of(alts[j, o, m])))
+        for mm, d in jobs[j][o]:
+            if mm == m:
+                # Only the start is given: the duration may have changed.
+                start.add_interval_var_solution(alts[j, o, m], presence=True,
+                                                start=s)
+            else:
+                start.add_interval_var_solution(alts[j, o, mm], presence=False)
+    mdl.add(mdl.minimize(makespan(mdl, ops) + mdl.sum(penalties)))
+    mdl.set_starting_point(start)
+    sol = mdl.solve(TimeLimit=time_limit, LogVerbosity='Quiet')
+    if not sol:
+        return None
+    return read_schedule(sol, alts)
+
+
+def print_schedule(schedule, old=None):
+    for (j, o) in sorted(schedule):
+        m, s, e = schedule[j, o]
+        note = ''
+        if old is not None:
+            om, os, oe = old[j, o]
+            if om != m:
+                note = '  (was M%d at %d)' % (om, os)
+            elif os != s:
+                note = '  (was at %d)' % os
+        print('  J%d_O%d: M%d [%d, %d)%s' % (j, o, m, s, e, note))
+
+
+if __name__ == "__main__":
+    mdl, ops, alts = build(JOBS)
+    mdl.add(mdl.minimize(makespan(mdl, ops)))
+    sol = mdl.solve(TimeLimit=10, LogVerbosity='Quiet')
+    if not sol:
+        print('No initial schedule found')
+    else:
+        old = read_schedule(sol, alts)
+        print('Initial schedule:')
+        print_schedule(old)
+
+        # At time 4 machine 1 goes down until time 9 and the second
+        # operation of job 0 takes 2 units longer than planned.
+        now = 4
+        durations = {(0, 1, m): d + 2 for m, d in JOBS[0][1]}
+        new = repair(JOBS, old, now, durations=durations,
+                     downtime=(1, now, 9))
+        if new is None:
+            print('No repair found')
+        else:
+            print('Repaired schedule:')
+            print_schedule(new, old)
@@ -0,0 +1,100 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to implement a soft time limit with a gap dependent
+# extension in an info callback.
+#
+# The policy is: stop after SOFT seconds, unless the relative gap is already
+# below GAP and the gap improved during the last WINDOW seconds. In that case
+# keep going, but never longer than HARD seconds. This is a pattern that is
+# frequently implemented in production deployments where a little extra time
+# is acceptable if the solve is close to finishing.
+#
+# Usage: python soft_time_limit.py <model file> <soft> <hard> <gap>
+import sys
+
+from cplex.callbacks import MIPInfoCallback
+
+from docplex.mp.callbacks.cb_mixin import *
+from docplex.mp.model_reader import ModelReader
+
+
+class TerminationPolicy(object):
+    """Decides when to stop based on elapsed time and gap history."""
+
+    def __init__(self, soft, hard, gap, window=10.0, min_improvement=1e-4):
+        self.soft = soft
+        self.hard = hard
+        self.gap = gap
+        self.window = window
+        self.min_improvement = min_improvement
+        # List of (time, gap) pairs at which the gap improved.
+        self.history = list()
+        self.reason = None
+
+    def record(self, elapsed, gap):
+        if len(self.history) == 0 or \
+           gap < self.history[-1][1] - self.min_improvement:
+            self.history.append((elapsed, gap))
+
+    def improving(self, elapsed):
+        return len(self.history) > 0 and \
+            self.history[-1][0] >= elapsed - self.window
+
+    def should_stop(self, elapsed, gap):
+        """Return True if the solve should stop. GAP is None if there is no
+        incumbent yet."""
+        if gap is not None:
+            self.record(elapsed, gap)
+        if elapsed < self.soft:
+            return False
+        if elapsed >= self.hard:
+            self.reason = 'hard time limit'
+            return True
+        if gap is not None and gap <= self.gap and self.improving(elapsed):
+            # Extend
+            return False
+        self.reason = 'soft time limit'
+        return True
+
+
+class PolicyCallback(ModelCallbackMixin, MIPInfoCallback):
+    def __init__(self, env):
+        MIPInfoCallback.__init__(self, env)
+        ModelCallbackMixin.__init__(self)
+        self.policy = None
+        self.extended = False
+
+    def __call__(self):
+        elapsed = self.get_time() - self.get_start_time()
+        gap = self.get_MIP_relative_gap() if self.has_incumbent() else None
+        if self.policy.should_stop(elapsed, gap):
+            print('Stopping after %.1f seconds (%s), gap %s' %
+                  (elapsed, self.policy.reason,
+                   'n/a' if gap is None else '%.4f%%' % (100.0 * gap)))
+            self.abort()
+        elif elapsed >= self.policy.soft and not self.extended:
+            self.extended = True
+            print('Extending solve beyond %.1f seconds, gap %.4f%%' %
+                  (self.policy.soft, 100.0 * gap))
+
+
+def solve_with_policy(mdl, policy, **kwargs):
+    cb = mdl.register_callback(PolicyCallback)
+    cb.policy = policy
+    # Safety net in case the callback is not invoked frequently enough.
+    mdl.parameters.timelimit = policy.hard
+    return mdl.solve(**kwargs)
+
+
+if __name__ == "__main__":
+    m = ModelReader.read(sys.argv[1])
+    policy = TerminationPolicy(soft=float(sys.argv[2]), hard=float(sys.argv[3]),
+                               gap=float(sys.argv[4]))
+    sol = solve_with_policy(m, policy, log_output=True)
+    if sol is not None:
+        print('Objective: %f' % sol.get_objective_value())
+    m.end()
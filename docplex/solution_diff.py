@@ -0,0 +1,75 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to compare two solutions of the same (or a similar)
+# model and report what changed, grouped by variable family.
+#
+# Solutions are dictionaries that map variable names to values, for example
+# as written by save_values() below. Variables that exist in only one of the
+# solutions are treated as 0 in the other. The family of a variable is
+# derived from its name; by default it is the part before the first '_', so
+# 'ship_3_7' and 'ship_1_2' are in family 'ship'. Changes smaller than the
+# given tolerances are ignored as noise.
+#
+# Usage: python solution_diff.py <old.json> <new.json> [tolerance]
+import json
+import sys
+from collections import namedtuple
+
+Change = namedtuple('Change', ['name', 'old', 'new'])
+
+
+def save_values(sol, filename):
+    """Save the values of all variables in SOL by name."""
+    with open(filename, 'w') as f:
+        json.dump({v.name: sol.get_value(v)
+                   for v in sol.model.iter_variables()}, f)
+
+
+def load_values(filename):
+    with open(filename, 'r') as f:
+        return json.load(f)
+
+
+def default_family(name):
+    return name.split('_', 1)[0]
+
+
+def diff(old, new, family=default_family, abs_tol=1e-6, rel_tol=0.0):
+    """Compare the solutions OLD and NEW.
+    Returns a dictionary that maps families to lists of changes."""
+    changes = dict()
+    for name in sorted(set(old) | set(new)):
+        a = old.get(name, 0.0)
+        b = new.get(name, 0.0)
+        if abs(a - b) <= max(abs_tol, rel_tol * max(abs(a), abs(b))):
+            continue
+        changes.setdefault(family(name), []).append(Change(name, a, b))
+    return changes
+
+
+def summary(changes):
+    """Turn the result of diff() into a structure that can be written as
+    JSON."""
+    result = dict()
+    for fam, lst in changes.items():
+        result[fam] = {
+            'count': len(lst),
+            'total_delta': sum(c.new - c.old for c in lst),
+            'changes': [{'name': c.name, 'old': c.old, 'new': c.new}
+                        for c in lst],
+        }
+    return result
+
+
+if __name__ == "__main__":
+    tol = 1e-6
+    if len(sys.argv) > 3:
+        tol = float(sys.argv[3])
+    changes = diff(load_values(sys.argv[1]), load_values(sys.argv[2]),
+                   abs_tol=tol)
+    json.dump(summary(changes), sys.stdout, indent=2, sort_keys=True)
+    print()
@@ -0,0 +1,80 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to confine a solve to a budget of threads, memory and
+# disk, so that several solves on the same machine do not interfere.
+#
+# The limits are set with CPLEX parameters:
+# - threads limits the number of threads,
+# - workmem is the working memory CPLEX tries to stay below; when the tree
+#   gets larger, nodes are written to node files (mip.strategy.file = 3,
+#   compressed node files on disk),
+# - mip.limits.treememory is a hard limit on the size of the tree, in memory
+#   and in node files together. CPLEX stops when it is reached,
+# - workdir is the directory for node files. Each solve gets its own
+#   temporary directory, so that concurrent solves do not share scratch
+#   files. The directory is removed when the sandbox is left, also if the
+#   solve raises an exception. It is not removed if the process is killed or
+#   the interpreter crashes; clean up directories with the 'cplex_' prefix
+#   in the scratch directory from time to time.
+# The previous parameter values are restored when the sandbox is left.
+#
+# The sandbox only applies to local solves in the calling process. Solves
+# that are sent to a batch or remote service run with the resources and the
+# scratch space of that service, which this file does not control.
+import random
+import shutil
+import tempfile
+from contextlib import contextmanager
+
+from docplex.mp.model import Model
+
+
+@contextmanager
+def sandbox(mdl, threads=1, memory_mb=1024, tree_mb=None, scratch=None):
+    """Context manager that limits the resources of solves of MDL.
+    MEMORY_MB is the working memory, TREE_MB the hard limit on the tree
+    size (default: 4 * MEMORY_MB). SCRATCH is the parent directory of the
+    private working directory (default: the system temporary directory)."""
+    params = mdl.parameters
+    settings = [(params.threads, threads),
+                (params.workmem, memory_mb),
+                (params.mip.strategy.file, 3),
+                (params.mip.limits.treememory, tree_mb or 4 * memory_mb)]
+    workdir = tempfile.mkdtemp(prefix='cplex_', dir=scratch)
+    settings.append((params.workdir, workdir))
+    saved = [(p, p.get()) for p, v in settings]
+    try:
+        for p, v in settings:
+            p.set(v)
+        yield workdir
+    finally:
+        for p, v in saved:
+            p.set(v)
+        shutil.rmtree(workdir, ignore_errors=True)
+
+
+if __name__ == "__main__":
+    rnd = random.Random(11)
+    n = 40
+    Items = range(n)
+    weight = [rnd.randint(20, 80) for i in Items]
+    value = [w + rnd.randint(0, 20) for w in weight]
+
+    with Model(name='knapsack') as m:
+        x = m.binary_var_list(Items, name='x')
+        for k in range(5):
+            m.add_constraint(m.sum(rnd.randint(1, 10) * x[i] for i in Items)
+                             <= 10 * n // 4)
+        m.add_constraint(m.sum(weight[i] * x[i] for i in Items) <= 800)
+        m.maximize(m.sum(value[i] * x[i] for i in Items))
+        m.parameters.timelimit = 30
+        with sandbox(m, threads=2, memory_mb=256) as workdir:
+            print('Solving with 2 threads and node files in %s' % workdir)
+            sol = m.solve()
+        print('Status: %s' % m.solve_details.status)
+        if sol is not None:
+            print('Objective: %g' % sol.get_objective_value())
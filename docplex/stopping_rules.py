@@ -0,0 +1,140 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to express custom stopping rules for a MIP solve
+# declaratively instead of writing a callback for each of them.
+#
+# A rule looks at the progress of the solve (elapsed time, incumbent, best
+# bound, gap) and says whether to stop. Rules are combined with & (all must
+# hold) and | (any must hold), for example
+#   (no_improvement(60) & gap_below(0.02)) | elapsed(600)
+# stops if the incumbent did not improve for 60 seconds while the gap is
+# below 2%, or after 10 minutes. A single info callback evaluates the rule
+# and aborts the solve when it holds; see soft_time_limit.py for a policy
+# that is coded by hand in the same kind of callback.
+#
+# Rules can have state (no_improvement() remembers when the incumbent last
+# improved), so all parts of a combined rule are evaluated each time.
+#
+# Usage: python stopping_rules.py <model file>
+import sys
+
+from cplex.callbacks import MIPInfoCallback
+
+from docplex.mp.callbacks.cb_mixin import *
+from docplex.mp.model_reader import ModelReader
+
+
+class Progress(object):
+    """Snapshot of the progress of a solve. Incumbent and gap are None if
+    there is no incumbent yet."""
+
+    def __init__(self, elapsed, incumbent, bound, gap, minimize):
+        self.elapsed = elapsed
+        self.incumbent = incumbent
+        self.bound = bound
+        self.gap = gap
+        self.minimize = minimize
+
+
+class Rule(object):
+    """A stopping rule: a predicate on Progress with a description."""
+
+    def __init__(self, predicate, description):
+        self.predicate = predicate
+        self.description = description
+
+    def __call__(self, progress):
+        return self.predicate(progress)
+
+    def __and__(self, other):
+        return Rule(lambda p: all([self(p), other(p)]),
+                    '(%s and %s)' % (self.description, other.description))
+
+    def __or__(self, other):
+        return Rule(lambda p: any([self(p), other(p)]),
+                    '(%s or %s)' % (self.description, other.description))
+
+    def __str__(self):
+        return self.description
+
+
+def elapsed(seconds):
+    return Rule(lambda p: p.elapsed >= seconds,
+                'after %g seconds' % seconds)
+
+
+def gap_below(gap):
+    return Rule(lambda p: p.gap is not None and p.gap <= gap,
+                'gap below %g%%' % (100.0 * gap))
+
+
+def objective_reaches(value):
+    """Stop when the incumbent is at least as good as VALUE."""
+    def reached(p):
+        if p.incumbent is None:
+            return False
+        return p.incumbent <= value if p.minimize else p.incumbent >= value
+    return Rule(reached, 'objective reaches %g' % value)
+
+
+def bound_crosses(value):
+    """Stop when the best bound proves that VALUE cannot be reached."""
+    def crossed(p):
+        return p.bound > value if p.minimize else p.bound < value
+    return Rule(crossed, 'bound crosses %g' % value)
+
+
+def no_improvement(seconds, tol=1e-9):
+    """Stop when the incumbent did not improve for SECONDS."""
+    state = {'value': None, 'time': 0.0}
+
+    def stalled(p):
+        if p.incumbent is not None:
+            if state['value'] is None or \
+               abs(p.incumbent - state['value']) > tol * max(1.0, abs(p.incumbent)):
+                state['value'] = p.incumbent
+                state['time'] = p.elapsed
+        return state['value'] is not None and p.elapsed - state['time'] >= seconds
+    return Rule(stalled, 'no improvement for %g seconds' % seconds)
+
+
+class RuleCallback(ModelCallbackMixin, MIPInfoCallback):
+    def __init__(self, env):
+        MIPInfoCallback.__init__(self, env)
+        ModelCallbackMixin.__init__(self)
+        self.rule = None
+        self.minimize = True
+
+    def __call__(self):
+        has = self.has_incumbent()
+        progress = Progress(self.get_time() - self.get_start_time(),
+                            self.get_incumbent_objective_value() if has else None,
+                            self.get_best_objective_value(),
+                            self.get_MIP_relative_gap() if has else None,
+                            self.minimize)
+        if self.rule(progress):
+            print('Stopping after %.1f seconds: %s' %
+                  (progress.elapsed, self.rule))
+            self.abort()
+
+
+def solve_with_rule(mdl, rule, **kwargs):
+    """Solve MDL and stop as soon as RULE holds."""
+    cb = mdl.register_callback(RuleCallback)
+    cb.rule = rule
+    cb.minimize = mdl.is_minimized()
+    return mdl.solve(**kwargs)
+
+
+if __name__ == "__main__":
+    m = ModelReader.read(sys.argv[1])
+    rule = (no_improvement(60) & gap_below(0.02)) | elapsed(600)
+    print('Stopping rule: %s' % rule)
+    sol = solve_with_rule(m, rule, log_output=True)
+    if sol is not None:
+        print('Objective: %f' % sol.get_objective_value())
+    m.end()
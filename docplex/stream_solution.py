@@ -0,0 +1,76 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to write the solution of a huge model without holding
+# all values in memory at once.
+#
+# Values and names are queried from CPLEX in chunks of consecutive variable
+# indices and only values above a threshold are written. Output is either
+# NDJSON (one JSON object per line) or CSV. The CPLEX Python API is used
+# directly, since for very large models creating a docplex model object for
+# each variable is not necessary.
+#
+# Usage: python stream_solution.py <model file> <output file> [threshold]
+#        The output format is CSV if the output file ends in .csv and NDJSON
+#        otherwise.
+import json
+import sys
+
+import cplex
+
+
+def nonzeros(cpx, tol=1e-6, chunk=100000):
+    """Generate (name, value) pairs for all variables in the current
+    solution of CPX whose absolute value is larger than TOL."""
+    n = cpx.variables.get_num()
+    for begin in range(0, n, chunk):
+        end = min(begin + chunk, n) - 1
+        values = cpx.solution.get_values(begin, end)
+        names = None
+        for k, x in enumerate(values):
+            if abs(x) > tol:
+                if names is None:
+                    # Only query names for chunks that have nonzeros.
+                    names = cpx.variables.get_names(begin, end)
+                yield names[k], x
+
+
+def write_ndjson(f, pairs):
+    count = 0
+    for name, x in pairs:
+        f.write(json.dumps({'name': name, 'value': x}))
+        f.write('\n')
+        count += 1
+    return count
+
+
+def write_csv(f, pairs):
+    count = 0
+    f.write('name,value\n')
+    for name, x in pairs:
+        f.write('"%s",%.17g\n' % (name.replace('"', '""'), x))
+        count += 1
+    return count
+
+
+if __name__ == "__main__":
+    tol = 1e-6
+    if len(sys.argv) > 3:
+        tol = float(sys.argv[3])
+    cpx = cplex.Cplex(sys.argv[1])
+    cpx.solve()
+    if not cpx.solution.is_primal_feasible():
+        print('No solution available')
+        sys.exit(1)
+    output = sys.argv[2]
+    with open(output, 'w') as f:
+        if output.endswith('.csv'):
+            count = write_csv(f, nonzeros(cpx, tol))
+        else:
+            count = write_ndjson(f, nonzeros(cpx, tol))
+    print('Wrote %d of %d values to %s' %
+          (count, cpx.variables.get_num(), output))
+    cpx.end()
@@ -0,0 +1,171 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to catch common modeling mistakes while the model is
+# built instead of after an unexpected solve result.
+#
+# StrictModel is a Model that raises an exception when
+# - a constraint uses a variable that belongs to another model (typically a
+#   variable left over from a previous model in a loop),
+# - the same constraint object is added twice,
+# - a constraint with the same content as an existing one is added (this
+#   usually means a loop runs over the wrong index set),
+# - a constraint name is used twice, so that LP files and conflict reports
+#   become ambiguous.
+# check_variable_names() reports variables with the same name.
+#
+# Linear constraints added with add_constraint(), add_constraints() or add()
+# get all checks. Ranges and indicators added with add_range() and
+# add_indicator() get the name and foreign variable checks, but are not
+# compared for duplicate content. Other constraint types (quadratic, SOS,
+# logical) only get the name check, and only when added with add().
+#
+# All checks are done in Python for each added constraint, so they cost
+# time. Use StrictModel while developing a model and Model in production.
+from docplex.mp.constr import LinearConstraint
+from docplex.mp.model import Model
+from docplex.mp.utils import DOcplexException
+
+
+class StrictModelError(Exception):
+    pass
+
+
+class StrictModel(Model):
+    """Model that checks each added linear constraint."""
+
+    def __init__(self, *args, **kwargs):
+        Model.__init__(self, *args, **kwargs)
+        self._strict_names = set()
+        self._strict_keys = dict()
+
+    def _check_name(self, name):
+        if name and name in self._strict_names:
+            raise StrictModelError('Duplicate constraint name %s' % name)
+
+    def _check_vars(self, variables, what):
+        for v in variables:
+            if v.model is not self:
+                raise StrictModelError('Variable %s of %s belongs to model %s' %
+                                       (v, what, v.model.name))
+
+    def _strict_key(self, ct):
+        expr = ct.get_left_expr() - ct.get_right_expr()
+        sense = ct.sense.name
+        if sense == 'GE':
+            expr = -expr
+            sense = 'LE'
+        terms = list()
+        for v, coef in expr.iter_terms():
+            self._check_vars([v], 'constraint %s' % ct)
+            if coef != 0:
+                terms.append((v.index, coef))
+        return sense, expr.get_constant(), tuple(sorted(terms))
+
+    def add_constraint(self, ct, ctname=None):
+        if ct.model is not self:
+            raise StrictModelError('Constraint %s belongs to model %s' %
+                                   (ct, ct.model.name))
+        if ct.has_valid_index():
+            raise StrictModelError('Constraint %s was already added' % ct)
+        name = ctname or ct.name
+        self._check_name(name)
+        key = None
+        if isinstance(ct, LinearConstraint):
+            key = self._strict_key(ct)
+            if key in self._strict_keys:
+                raise StrictModelError('Constraint %s has the same content as '
+                                       '%s' % (ct, self._strict_keys[key]))
+        ct = Model.add_constraint(self, ct, ctname)
+        if name:
+            self._strict_names.add(name)
+        if key is not None:
+            self._strict_keys[key] = ct
+        return ct
+
+    def add_constraints(self, cts, names=None):
+        if names is None:
+            return [self.add_constraint(ct) for ct in cts]
+        return [self.add_constraint(ct, name) for ct, name in zip(cts, names)]
+
+    def add(self, ct, name=None):
+        if isinstance(ct, LinearConstraint):
+            return self.add_constraint(ct, name)
+        if isinstance(ct, (list, tuple)):
+            return self.add_constraints(ct, name)
+        self._check_name(name or getattr(ct, 'name', None))
+        ct = Model.add(self, ct, name)
+        if getattr(ct, 'name', None):
+            self._strict_names.add(ct.name)
+        return ct
+
+    def add_range(self, lb, expr, ub, rng_name=None):
+        self._check_name(rng_name)
+        self._check_vars([v for v, coef in expr.iter_terms()],
+                         'range %s' % (rng_name or expr))
+        ct = Model.add_range(self, lb, expr, ub, rng_name)
+        if rng_name:
+            self._strict_names.add(rng_name)
+        return ct
+
+    def add_indicator(self, binary_var, linear_ct, active_value=1, name=None):
+        self._check_name(name)
+        self._check_vars([binary_var], 'indicator %s' % (name or linear_ct))
+        if isinstance(linear_ct, LinearConstraint):
+            expr = linear_ct.get_left_expr() - linear_ct.get_right_expr()
+            self._check_vars([v for v, coef in expr.iter_terms()],
+                             'indicator %s' % (name or linear_ct))
+        ct = Model.add_indicator(self, binary_var, linear_ct, active_value, name)
+        if name:
+            self._strict_names.add(name)
+        return ct
+
+    def remove_constraint(self, ct):
+        if isinstance(ct, str):
+            name = ct
+            ct = self.get_constraint_by_name(name)
+            if ct is None:
+                raise StrictModelError('No constraint named %s' % name)
+        self._strict_names.discard(ct.name)
+        if isinstance(ct, LinearConstraint):
+            self._strict_keys.pop(self._strict_key(ct), None)
+        Model.remove_constraint(self, ct)
+
+    def check_variable_names(self):
+        """Return a dictionary that maps variable names used more than once
+        to the variables with this name."""
+        byname = dict()
+        for v in self.iter_variables():
+            if v.name:
+                byname.setdefault(v.name, []).append(v)
+        return {name: vs for name, vs in byname.items() if len(vs) > 1}
+
+
+if __name__ == "__main__":
+    Items = range(5)
+    with StrictModel(name='first') as m1:
+        old = m1.binary_var_list(Items, name='x')
+
+    with StrictModel(name='strict') as m:
+        x = m.binary_var_list(Items, name='x')
+        y = m.binary_var_list(Items, name='x')
+        m.add_constraint(m.sum(x) <= 2, 'cardinality')
+
+        def attempt(what, f):
+            try:
+                f()
+                print('%s: accepted' % what)
+            except (StrictModelError, DOcplexException) as e:
+                print('%s: %s' % (what, e))
+
+        ct = x[0] + x[1] <= 1
+        attempt('new constraint', lambda: m.add_constraint(ct, 'pair'))
+        attempt('same object again', lambda: m.add_constraint(ct))
+        attempt('same content', lambda: m.add_constraint(1 >= x[1] + x[0]))
+        attempt('same name', lambda: m.add_constraint(x[2] <= x[3], 'pair'))
+        attempt('foreign variable', lambda: m.add_constraint(x[4] + old[4] <= 1))
+        for name, vs in m.check_variable_names().items():
+            print('Variable name %s is used %d times' % (name, len(vs)))
@@ -0,0 +1,108 @@
+# --------------------------------------------------------------------------
+# Source file provided under Apache License, Version 2.0, January 2004,
+# http://www.apache.org/licenses/
+# (c) Copyright IBM Corp. 2026
+# --------------------------------------------------------------------------
+
+# This file shows how to reuse a solution of a previous version of a model as
+# a MIP start for a new version of the model, even if variables were added
+# or removed in between.
+#
+# Values are mapped from the old to the new model by variable name:
+# - values for variables that no longer exist are dropped,
+# - variables that are new get a value from a user supplied heuristic, or
+#   are left out so that CPLEX completes the partial start.
+# The start is submitted with effort level 'repair' so that CPLEX tries to
+# fix it up if the translated start violates some of the new constraints.
+import json
+import os
+import random
+import tempfile
+
+from docplex.mp.constants import EffortLevel
+from docplex.mp.model import Model
+from docplex.mp.solution import SolveSolution
+
+
+def save_solution(sol, filename):
+    """Save the values of all variables in SOL by name."""
+    with open(filename, 'w') as f:
+        json.dump({v.name: sol.get_value(v)
+                   for v in sol.model.iter_variables()}, f)
+
+
+def load_solution(filename):
+    with open(filename, 'r') as f:
+        return json.load(f)
+
+
+def translate_start(mdl, old_values, fill=None):
+    """Create a MIP start for MDL from the name/value pairs in OLD_VALUES.
+
+    FILL is an optional function that is called for each variable of MDL
+    that has no value in OLD_VALUES. It returns a value for the variable or
+    None to leave the variable out of the start.
+    """
+    values = dict()
+    mapped = 0
+    filled = 0
+    for v in mdl.iter_variables():
+        if v.name in old_values:
+            val = old_values[v.name]
+            # Bounds may have changed, so clip the value.
+            values[v] = min(max(val, v.lb), v.ub)
+            mapped += 1
+        elif fill is not None:
+            val = fill(v)
+            if val is not None:
+                values[v] = val
+                filled += 1
+    names = set(v.name for v in mdl.iter_variables())
+    dropped = sum(1 for name in old_values if name not in names)
+    print('Start translation: %d mapped, %d filled, %d dropped, %d open' %
+          (mapped, filled, dropped, mdl.number_of_variables - mapped - filled))
+    return SolveSolution(mdl, var_value_map=values)
+
+
+def build_model(items, capacity):
+    """Build a knapsack model for ITEMS, a dictionary that maps item ids
+    to (weight, profit) pairs."""
+    m = Model(name='knapsack')
+    take = {i: m.binary_var(name='take_%s' % i) for i in items}
+    m.add_constraint(m.sum(items[i][0] * take[i] for i in items) <= capacity,
+                     'capacity')
+    m.maximize(m.sum(items[i][1] * take[i] for i in items))
+    return m
+
+
+if __name__ == "__main__":
+    rnd = random.Random(42)
+    items = {i: (rnd.randint(5, 30), rnd.randint(5, 40)) for i in range(30)}
+
+    # The solution of the first version is kept in a file, as it would be
+    # between two runs.
+    with tempfile.TemporaryDirectory() as tmp:
+        saved = os.path.join(tmp, 'knapsack_v1.json')
+        # Solve the first version of the model and store the solution.
+        with build_model(items, 200) as m:
+            sol = m.solve()
+            assert sol is not None
+            print('Version 1: %f' % sol.get_objective_value())
+            save_solution(sol, saved)
+
+        # In the next version some items were removed and new items were added.
+        del items[3]
+        del items[7]
+        for i in range(30, 35):
+            items[i] = (rnd.randint(5, 30), rnd.randint(5, 40))
+
+        with build_model(items, 180) as m:
+            old = load_solution(saved)
+            # New items are not taken in the start. Since the capacity was
+            # reduced the start may be infeasible, which is fixed by the repair
+            # effort level.
+            start = translate_start(m, old, fill=lambda v: 0)
+            m.add_mip_start(start, effort_level=EffortLevel.Repair)
+            sol = m.solve(log_output=True)
+            assert sol is not None
+            print('Version 2: %f' % sol.get_objective_value())
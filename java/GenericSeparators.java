@@ -0,0 +1,221 @@
+// Untested code snippet. NO WARRANTY!
+import ilog.cplex.IloCplex;
+import ilog.concert.IloException;
+import ilog.concert.IloLPMatrix;
+import ilog.concert.IloMPModeler;
+import ilog.concert.IloNumVar;
+import ilog.concert.IloRange;
+import java.util.Collection;
+import java.util.Vector;
+
+/** Example class that illustrates how to have multiple separators with
+ * a single generic callback in CPLEX.
+ *
+ * In contrast to {@link MultipleSeparators} the separators here are invoked
+ * from a generic callback. Generic callbacks are invoked concurrently from
+ * all threads that CPLEX uses, so separators must not share mutable state.
+ * To make this easy, each separator invocation receives a
+ * {@link SeparationContext} that
+ * <ul>
+ *    <li>holds a private copy of the candidate solution,</li>
+ *    <li>provides scratch buffers that are owned by the invoking thread and
+ *        can be reused between invocations without synchronization,</li>
+ *    <li>can only be used by the invoking thread and only for the duration
+ *        of the invocation. Any other use throws an exception.</li>
+ * </ul>
+ */
+public final class GenericSeparators {
+
+   /** Context passed to separators.
+    * There is exactly one instance of this class for each thread that
+    * CPLEX uses. An instance is created when the thread starts and is
+    * discarded when the thread ends.
+    */
+   public static final class SeparationContext {
+      /** The thread that owns this context. */
+      private Thread owner = null;
+      /** The index of the CPLEX thread that owns this context. */
+      private final int threadId;
+      /** Values of the current candidate solution. */
+      private double[] values = null;
+      /** Thread-local scratch buffers. */
+      private final double[] doubleScratch;
+      private final int[] intScratch;
+
+      SeparationContext(int threadId, int numVars) {
+         this.threadId = threadId;
+         this.doubleScratch = new double[numVars];
+         this.intScratch = new int[numVars];
+      }
+
+      /** Make the context valid for the calling thread. */
+      void enter(double[] values) {
+         this.owner = Thread.currentThread();
+         this.values = values;
+      }
+
+      /** Invalidate the context. */
+      void leave() {
+         this.owner = null;
+         this.values = null;
+      }
+
+      /** Check that the context is used correctly. */
+      private void check() {
+         if ( owner == null )
+            throw new IllegalStateException("context used outside of separation");
+         if ( owner != Thread.currentThread() )
+            throw new IllegalStateException("context used from foreign thread");
+      }
+
+      /** Get the index of the CPLEX thread that invokes the separator. */
+      public int getThreadId() { check(); return threadId; }
+
+      /** Get the value of variable <code>j</code> in the candidate solution.
+       * @param j The index of the variable as passed to the callback's
+       *          constructor.
+       * @return The value of the variable.
+       */
+      public double getValue(int j) { check(); return values[j]; }
+
+      /** Get a scratch buffer of doubles with one element per variable.
+       * The buffer is owned by the current thread, its contents are
+       * undefined on entry to the separator.
+       */
+      public double[] getDoubleScratch() { check(); return doubleScratch; }
+
+      /** Get a scratch buffer of ints with one element per variable.
+       * The buffer is owned by the current thread, its contents are
+       * undefined on entry to the separator.
+       */
+      public int[] getIntScratch() { check(); return intScratch; }
+   }
+
+   /** Interface for separators.
+    * Separators implement this interface in order to be invoked from the
+    * callback's <code>invoke()</code> method. The same separator instance
+    * is invoked concurrently from different threads, so any state that is
+    * modified during separation must be kept in the context.
+    */
+   public interface Separator {
+      /** Separate constraints for the candidate solution in
+       * <code>ctx</code>.
+       * @param ctx The separation context for the current invocation.
+       * @return A (potentially empty) list of violated constraints.
+       */
+      public Collection<IloRange> separate(SeparationContext ctx) throws IloException;
+   }
+
+   /** The generic callback that wraps the multiple separators. */
+   public static class Callback implements IloCplex.Callback.Function {
+
+      /** The separators that are invoked from the callback. */
+      private final Collection<Separator> separators = new Vector<Separator>();
+      /** The variables for which candidate values are queried. */
+      private final IloNumVar[] x;
+      /** Separation contexts, indexed by thread id. */
+      private final SeparationContext[] contexts;
+
+      public Callback(Collection<Separator> separators, IloNumVar[] x,
+                      int numThreads)
+      {
+         this.separators.addAll(separators);
+         this.x = x;
+         this.contexts = new SeparationContext[numThreads];
+      }
+
+      public void invoke(IloCplex.Callback.Context context) throws IloException {
+         int threadId = context.getIntInfo(IloCplex.Callback.Context.Info.ThreadId);
+         // Each thread only ever touches its own slot in contexts[], so no
+         // synchronization is required.
+         if ( threadId >= contexts.length )
+            throw new IloException("Thread " + threadId + " exceeds the " +
+                                   contexts.length + " threads the callback " +
+                                   "was created for");
+         if ( context.inThreadUp() ) {
+            contexts[threadId] = new SeparationContext(threadId, x.length);
+         }
+         else if ( context.inThreadDown() ) {
+            contexts[threadId] = null;
+         }
+         else if ( context.inCandidate() ) {
+            if ( !context.isCandidatePoint() )
+               throw new IloException("Unbounded solution");
+            SeparationContext ctx = contexts[threadId];
+            if ( ctx == null )
+               throw new IloException("No separation context for thread " +
+                                      threadId + ": the callback must be " +
+                                      "used with the ThreadUp context");
+            Vector<IloRange> violated = new Vector<IloRange>();
+            ctx.enter(context.getCandidatePoint(x));
+            try {
+               // Go through all separators
+               for (Separator s : separators)
+                  // and collect all violated constraints found
+                  violated.addAll(s.separate(ctx));
+            }
+            finally {
+               ctx.leave();
+            }
+            if ( violated.size() > 0 )
+               context.rejectCandidate(violated.toArray(new IloRange[violated.size()]));
+         }
+      }
+   }
+
+   /** Example separator. */
+   private static final class Example implements Separator {
+      /** The factory that is used to create violated constraints. */
+      private final IloMPModeler factory;
+      /** Variables for the separation algorithm. */
+      private final IloNumVar[] x;
+      public Example(IloMPModeler factory, IloNumVar[] x) {
+         this.factory = factory;
+         this.x = x;
+      }
+
+      public Collection<IloRange> separate(SeparationContext ctx) throws IloException {
+         // Require any variable to have a value <= 100.
+         // Collect indices of violated variables in the thread-local scratch
+         // buffer first.
+         int[] idx = ctx.getIntScratch();
+         int cnt = 0;
+         for (int i = 0; i < x.length; ++i) {
+            if ( ctx.getValue(i) > 100 )
+               idx[cnt++] = i;
+         }
+         Collection<IloRange> violated = new Vector<IloRange>();
+         for (int k = 0; k < cnt; ++k)
+            violated.add(factory.le(x[idx[k]], 100));
+         System.out.println("Thread " + ctx.getThreadId() + ": " +
+                            violated.size() + " violated constraints found");
+         return violated;
+      }
+   }
+
+   public static void main(String[] args) throws IloException {
+      for (String model : args) {
+         IloCplex cplex = new IloCplex();
+         try {
+            cplex.importModel(model);
+            IloNumVar[] x = ((IloLPMatrix)cplex.LPMatrixIterator().next()).getNumVars();
+            Vector<Separator> separators = new Vector<Separator>();
+            separators.add(new Example(cplex, x));
+            // CPLEX uses as many threads as the Threads parameter says, or
+            // one per core if the parameter is 0 (automatic).
+            int numThreads = cplex.getParam(IloCplex.Param.Threads);
+            if ( numThreads <= 0 )
+               numThreads = cplex.getNumCores();
+            Callback cb = new Callback(separators, x, numThreads);
+            cplex.use(cb,
+                      IloCplex.Callback.Context.Id.Candidate |
+                      IloCplex.Callback.Context.Id.ThreadUp |
+                      IloCplex.Callback.Context.Id.ThreadDown);
+            cplex.solve();
+         }
+         finally {
+            cplex.end();
+         }
+      }
+   }
+}